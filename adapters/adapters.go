@@ -0,0 +1,134 @@
+// Package adapters converts between glock.Clock and the clock interfaces of
+// other libraries: jonboulle/clockwork, benbjohnson/clock, and k8s.io/utils/clock.
+//
+// The adapters are defined structurally so that glock does not depend on those
+// modules. Because their clock interfaces return ticker and timer interfaces
+// declared in their own packages, the adapters are parameterized by those types,
+// which must be supplied explicitly:
+//
+//	var c clockwork.Clock = adapters.ToClockwork[clockwork.Ticker, clockwork.Timer](glock.NewMockClock())
+//	var k clock.WithTickerAndDelayedExecution = adapters.ToKubernetes[clock.Ticker, clock.Timer](glock.NewMockClock())
+//
+// Adapters in the other direction wrap a foreign clock in a glock.Clock:
+//
+//	var g glock.Clock = adapters.FromClockwork[clockwork.Ticker, clockwork.Timer](clockwork.NewFakeClock())
+//
+// The benbjohnson/clock interface returns pointers to concrete Ticker and Timer
+// structs, which cannot be constructed outside of that package, so a glock.Clock
+// can only be adapted to it in the other direction (see FromBenbjohnson).
+package adapters
+
+import (
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// ticker adapts a glock.Ticker to the ticker interfaces of other libraries.
+// As glock.Ticker cannot be reset, Reset replaces the underlying ticker. Ticks
+// are forwarded from the current underlying ticker to a single channel, so the
+// ticker's channel does not change.
+type ticker struct {
+	clock  glock.Clock
+	ch     chan time.Time
+	mu     sync.Mutex
+	ticker glock.Ticker
+	done   chan struct{}
+}
+
+func newTicker(clock glock.Clock, duration time.Duration) *ticker {
+	t := &ticker{clock: clock, ch: make(chan time.Time)}
+	t.start(duration)
+	return t
+}
+
+// Chan returns the ticker's channel (clockwork).
+func (t *ticker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+// C returns the ticker's channel (k8s.io/utils/clock).
+func (t *ticker) C() <-chan time.Time {
+	return t.ch
+}
+
+// Reset stops the ticker and restarts it with the given period.
+func (t *ticker) Reset(duration time.Duration) {
+	if duration <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+	t.start(duration)
+}
+
+// Stop stops the ticker.
+func (t *ticker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stop()
+}
+
+// start creates an underlying ticker with the given period and starts forwarding
+// its ticks. This method assumes the lock is held.
+func (t *ticker) start(duration time.Duration) {
+	t.ticker = t.clock.NewTicker(duration)
+	t.done = make(chan struct{})
+	go t.forward(t.ticker, t.done)
+}
+
+// stop stops the underlying ticker and the forwarding of its ticks. This method
+// assumes the lock is held.
+func (t *ticker) stop() {
+	if t.done == nil {
+		return
+	}
+
+	t.ticker.Stop()
+	close(t.done)
+	t.done = nil
+}
+
+// forward sends the ticks of the given underlying ticker on the adapter's channel
+// until the given done channel is closed.
+func (t *ticker) forward(ticker glock.Ticker, done chan struct{}) {
+	for {
+		select {
+		case now := <-ticker.Chan():
+			select {
+			case t.ch <- now:
+			case <-done:
+				return
+			}
+
+		case <-done:
+			return
+		}
+	}
+}
+
+// timer adapts a glock.Timer to the timer interfaces of other libraries.
+type timer struct {
+	glock.Timer
+}
+
+// C returns the timer's channel (k8s.io/utils/clock).
+func (t timer) C() <-chan time.Time {
+	return t.Chan()
+}
+
+// convert returns the given ticker or timer adapter as the foreign interface
+// type T. It panics if T is not an interface implemented by the adapter.
+func convert[T any](v interface{}) T {
+	converted, ok := v.(T)
+	if !ok {
+		panic("adapters: type argument must be an interface implemented by the adapter")
+	}
+
+	return converted
+}
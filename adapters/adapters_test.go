@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickerReset(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	ticker := newTicker(clock, time.Second)
+	defer ticker.Stop()
+
+	ticker.Reset(3 * time.Second)
+	clock.Advance(2 * time.Second)
+	assertNoValue(t, ticker.C())
+
+	clock.Advance(time.Second)
+	assertReceives(t, ticker.C(), time.Unix(3, 0))
+
+	assert.PanicsWithValue(t, "non-positive interval for Ticker.Reset", func() { ticker.Reset(0) })
+}
+
+func TestTickerResetKeepsChannel(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	ticker := newTicker(clock, time.Second)
+	defer ticker.Stop()
+
+	// Consumers commonly hold on to the channel while the ticker is reset elsewhere
+	ch := ticker.Chan()
+	clock.Advance(time.Second)
+	assertReceives(t, ch, time.Unix(1, 0))
+
+	ticker.Reset(2 * time.Second)
+	clock.Advance(2 * time.Second)
+	assertReceives(t, ch, time.Unix(3, 0))
+
+	ticker.Stop()
+	clock.Advance(2 * time.Second)
+	assertNoValue(t, ch)
+
+	ticker.Reset(time.Second)
+	clock.Advance(time.Second)
+	assertReceives(t, ch, time.Unix(6, 0))
+}
+
+func TestConvertNonInterface(t *testing.T) {
+	assert.Panics(t, func() { convert[*glock.MockTimer](timer{}) })
+}
+
+func assertReceives(t *testing.T, ch <-chan time.Time, expected time.Time) {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		assert.Equal(t, expected, value)
+	case <-time.After(time.Second):
+		t.Fatalf("expected a value")
+	}
+}
+
+func assertNoValue(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		t.Fatalf("unexpected value %s", value)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
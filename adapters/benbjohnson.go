@@ -0,0 +1,235 @@
+package adapters
+
+import (
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// BenbjohnsonTimer is the method set of *clock.Timer from benbjohnson/clock,
+// excluding its C field.
+type BenbjohnsonTimer interface {
+	Stop() bool
+	Reset(duration time.Duration) bool
+}
+
+// BenbjohnsonClock is the subset of the benbjohnson/clock.Clock method set
+// that can be used without access to the fields of *clock.Timer and
+// *clock.Ticker.
+type BenbjohnsonClock[Timer BenbjohnsonTimer] interface {
+	After(duration time.Duration) <-chan time.Time
+	AfterFunc(duration time.Duration, f func()) Timer
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Until(t time.Time) time.Duration
+	Sleep(duration time.Duration)
+}
+
+// fromBenbjohnson adapts a benbjohnson/clock.Clock to glock.Clock. Timers and
+// tickers are built on AfterFunc, as the channels of *clock.Timer and
+// *clock.Ticker are struct fields that an interface cannot reach.
+type fromBenbjohnson[Timer BenbjohnsonTimer] struct {
+	clock BenbjohnsonClock[Timer]
+}
+
+// FromBenbjohnson wraps the given benbjohnson/clock.Clock in a glock.Clock. The
+// type argument must be *clock.Timer.
+func FromBenbjohnson[Timer BenbjohnsonTimer](clock BenbjohnsonClock[Timer]) glock.Clock {
+	return &fromBenbjohnson[Timer]{clock: clock}
+}
+
+func (c *fromBenbjohnson[Timer]) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *fromBenbjohnson[Timer]) After(duration time.Duration) <-chan time.Time {
+	return c.clock.After(duration)
+}
+
+func (c *fromBenbjohnson[Timer]) Sleep(duration time.Duration) {
+	c.clock.Sleep(duration)
+}
+
+func (c *fromBenbjohnson[Timer]) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c *fromBenbjohnson[Timer]) Until(t time.Time) time.Duration {
+	return c.clock.Until(t)
+}
+
+func (c *fromBenbjohnson[Timer]) NewTicker(duration time.Duration) glock.Ticker {
+	return newBenbjohnsonTicker(c, duration)
+}
+
+func (c *fromBenbjohnson[Timer]) NewTimer(duration time.Duration) glock.Timer {
+	t := &benbjohnsonChanTimer[Timer]{
+		clock: c.clock,
+		ch:    make(chan time.Time, 1),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.arm(duration)
+	return t
+}
+
+func (c *fromBenbjohnson[Timer]) AfterFunc(duration time.Duration, f func()) glock.Timer {
+	return benbjohnsonTimer{BenbjohnsonTimer: c.clock.AfterFunc(duration, f)}
+}
+
+// benbjohnsonTimer adapts a *clock.Timer from benbjohnson/clock created by
+// AfterFunc to glock.Timer. Its channel is nil.
+type benbjohnsonTimer struct {
+	BenbjohnsonTimer
+}
+
+func (t benbjohnsonTimer) Chan() <-chan time.Time {
+	return nil
+}
+
+// benbjohnsonChanTimer is a glock.Timer built on AfterFunc. As with
+// time.NewTimer, a non-positive duration delivers a value immediately rather
+// than when the wrapped clock runs the callback. Each arming of the timer has
+// a generation, so a callback that runs after the timer was stopped or reset
+// delivers nothing.
+type benbjohnsonChanTimer[Timer BenbjohnsonTimer] struct {
+	clock      BenbjohnsonClock[Timer]
+	ch         chan time.Time
+	mu         sync.Mutex
+	timer      BenbjohnsonTimer // nil while the timer is not armed
+	generation int
+}
+
+func (t *benbjohnsonChanTimer[Timer]) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *benbjohnsonChanTimer[Timer]) Reset(duration time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := t.stop()
+	t.arm(duration)
+	return active
+}
+
+func (t *benbjohnsonChanTimer[Timer]) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stop()
+}
+
+// arm starts a new generation of the timer. This method assumes the lock is
+// held.
+func (t *benbjohnsonChanTimer[Timer]) arm(duration time.Duration) {
+	t.generation++
+	generation := t.generation
+
+	if duration <= 0 {
+		t.send()
+		return
+	}
+
+	t.timer = t.clock.AfterFunc(duration, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if generation == t.generation {
+			t.timer = nil
+			t.send()
+		}
+	})
+}
+
+// stop cancels the current generation of the timer, if it is armed. This
+// method assumes the lock is held.
+func (t *benbjohnsonChanTimer[Timer]) stop() bool {
+	t.generation++
+
+	if t.timer == nil {
+		return false
+	}
+
+	stopped := t.timer.Stop()
+	t.timer = nil
+	return stopped
+}
+
+// send delivers the current time unless a value is already pending. This
+// method assumes the lock is held.
+func (t *benbjohnsonChanTimer[Timer]) send() {
+	select {
+	case t.ch <- t.clock.Now():
+	default:
+	}
+}
+
+// benbjohnsonTicker is a glock.Ticker built on AfterFunc. Each tick re-arms the
+// timer for the following one.
+type benbjohnsonTicker struct {
+	clock    glock.Clock
+	ch       chan time.Time
+	mu       sync.Mutex
+	duration time.Duration
+	next     time.Time
+	timer    glock.Timer
+	stopped  bool
+}
+
+func newBenbjohnsonTicker(clock glock.Clock, duration time.Duration) *benbjohnsonTicker {
+	if duration <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	t := &benbjohnsonTicker{
+		clock:    clock,
+		ch:       make(chan time.Time, 1),
+		duration: duration,
+		next:     clock.Now().Add(duration),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timer = clock.AfterFunc(duration, t.tick)
+	return t
+}
+
+func (t *benbjohnsonTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *benbjohnsonTicker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopped = true
+	t.timer.Stop()
+}
+
+// tick sends the scheduled tick and arms a timer for the next one. Ticks that
+// were missed in the meantime are dropped, as they are by time.Ticker.
+func (t *benbjohnsonTicker) tick() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
+
+	select {
+	case t.ch <- t.next:
+	default:
+	}
+
+	now := t.clock.Now()
+	for !t.next.After(now) {
+		t.next = t.next.Add(t.duration)
+	}
+
+	t.timer = t.clock.AfterFunc(t.next.Sub(now), t.tick)
+}
@@ -0,0 +1,35 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/derision-test/glock/glocktest"
+)
+
+// benbjohnsonClock stands in for benbjohnson/clock.Clock. Its AfterFunc returns
+// an interface rather than *clock.Timer, which is all the adapter relies on.
+type benbjohnsonClock struct {
+	glock.Clock
+}
+
+func (c benbjohnsonClock) AfterFunc(duration time.Duration, f func()) BenbjohnsonTimer {
+	return c.Clock.AfterFunc(duration, f)
+}
+
+func TestBenbjohnsonConformance(t *testing.T) {
+	glocktest.RunClockConformance(t, func(t *testing.T) glocktest.Harness {
+		// Tickers are driven by AfterFunc, so callbacks must run before Advance
+		// returns for missed ticks to be dropped deterministically
+		clock := glock.NewMockClockAt(time.Unix(0, 0), glock.WithSynchronousAfterFunc())
+
+		return glocktest.Harness{
+			Clock:   FromBenbjohnson[BenbjohnsonTimer](benbjohnsonClock{clock}),
+			Advance: clock.Advance,
+			Unit:    time.Second,
+
+			DefersNonPositiveAfterFunc: true,
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// ClockworkTicker is the method set of clockwork.Ticker.
+type ClockworkTicker interface {
+	Chan() <-chan time.Time
+	Reset(duration time.Duration)
+	Stop()
+}
+
+// ClockworkTimer is the method set of clockwork.Timer.
+type ClockworkTimer interface {
+	Chan() <-chan time.Time
+	Reset(duration time.Duration) bool
+	Stop() bool
+}
+
+// ClockworkClock is the method set of clockwork.Clock with the given ticker and
+// timer types.
+type ClockworkClock[Ticker ClockworkTicker, Timer ClockworkTimer] interface {
+	After(duration time.Duration) <-chan time.Time
+	Sleep(duration time.Duration)
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Until(t time.Time) time.Duration
+	NewTicker(duration time.Duration) Ticker
+	NewTimer(duration time.Duration) Timer
+	AfterFunc(duration time.Duration, f func()) Timer
+}
+
+// Clockwork adapts a glock.Clock to clockwork.Clock. The type arguments must be
+// clockwork.Ticker and clockwork.Timer.
+type Clockwork[Ticker ClockworkTicker, Timer ClockworkTimer] struct {
+	clock glock.Clock
+}
+
+var _ ClockworkClock[ClockworkTicker, ClockworkTimer] = &Clockwork[ClockworkTicker, ClockworkTimer]{}
+
+// ToClockwork wraps the given clock in a clockwork.Clock.
+func ToClockwork[Ticker ClockworkTicker, Timer ClockworkTimer](clock glock.Clock) *Clockwork[Ticker, Timer] {
+	return &Clockwork[Ticker, Timer]{clock: clock}
+}
+
+func (c *Clockwork[Ticker, Timer]) After(duration time.Duration) <-chan time.Time {
+	return c.clock.After(duration)
+}
+
+func (c *Clockwork[Ticker, Timer]) Sleep(duration time.Duration) {
+	c.clock.Sleep(duration)
+}
+
+func (c *Clockwork[Ticker, Timer]) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *Clockwork[Ticker, Timer]) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c *Clockwork[Ticker, Timer]) Until(t time.Time) time.Duration {
+	return c.clock.Until(t)
+}
+
+func (c *Clockwork[Ticker, Timer]) NewTicker(duration time.Duration) Ticker {
+	return convert[Ticker](newTicker(c.clock, duration))
+}
+
+func (c *Clockwork[Ticker, Timer]) NewTimer(duration time.Duration) Timer {
+	return convert[Timer](timer{c.clock.NewTimer(duration)})
+}
+
+func (c *Clockwork[Ticker, Timer]) AfterFunc(duration time.Duration, f func()) Timer {
+	return convert[Timer](timer{c.clock.AfterFunc(duration, f)})
+}
+
+// fromClockwork adapts a clockwork.Clock to glock.Clock.
+type fromClockwork[Ticker ClockworkTicker, Timer ClockworkTimer] struct {
+	clock ClockworkClock[Ticker, Timer]
+}
+
+// FromClockwork wraps the given clockwork.Clock in a glock.Clock. The type
+// arguments must be clockwork.Ticker and clockwork.Timer.
+func FromClockwork[Ticker ClockworkTicker, Timer ClockworkTimer](clock ClockworkClock[Ticker, Timer]) glock.Clock {
+	return &fromClockwork[Ticker, Timer]{clock: clock}
+}
+
+func (c *fromClockwork[Ticker, Timer]) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *fromClockwork[Ticker, Timer]) After(duration time.Duration) <-chan time.Time {
+	return c.clock.After(duration)
+}
+
+func (c *fromClockwork[Ticker, Timer]) Sleep(duration time.Duration) {
+	c.clock.Sleep(duration)
+}
+
+func (c *fromClockwork[Ticker, Timer]) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c *fromClockwork[Ticker, Timer]) Until(t time.Time) time.Duration {
+	return c.clock.Until(t)
+}
+
+func (c *fromClockwork[Ticker, Timer]) NewTicker(duration time.Duration) glock.Ticker {
+	return c.clock.NewTicker(duration)
+}
+
+func (c *fromClockwork[Ticker, Timer]) NewTimer(duration time.Duration) glock.Timer {
+	return c.clock.NewTimer(duration)
+}
+
+func (c *fromClockwork[Ticker, Timer]) AfterFunc(duration time.Duration, f func()) glock.Timer {
+	return c.clock.AfterFunc(duration, f)
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/derision-test/glock/glocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// clockworkTicker and clockworkTimer stand in for the interfaces declared by
+// jonboulle/clockwork, which the adapters must be instantiated with.
+type (
+	clockworkTicker interface {
+		Chan() <-chan time.Time
+		Reset(duration time.Duration)
+		Stop()
+	}
+
+	clockworkTimer interface {
+		Chan() <-chan time.Time
+		Reset(duration time.Duration) bool
+		Stop() bool
+	}
+)
+
+func TestToClockwork(t *testing.T) {
+	mock := glock.NewMockClockAt(time.Unix(0, 0))
+	clock := ToClockwork[clockworkTicker, clockworkTimer](mock)
+
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	called := make(chan struct{}, 1)
+	clock.AfterFunc(time.Second, func() { called <- struct{}{} })
+
+	mock.Advance(time.Second)
+	assert.Equal(t, time.Unix(1, 0), clock.Now())
+	assertReceives(t, ticker.Chan(), time.Unix(1, 0))
+	mock.WaitForCallbacks()
+	assert.Len(t, called, 1)
+}
+
+func TestClockworkConformance(t *testing.T) {
+	glocktest.RunClockConformance(t, func(t *testing.T) glocktest.Harness {
+		h := glocktest.MockHarness(t)
+		h.Clock = FromClockwork[clockworkTicker, clockworkTimer](ToClockwork[clockworkTicker, clockworkTimer](h.Clock))
+		return h
+	})
+}
@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// KubernetesTicker is the method set of k8s.io/utils/clock.Ticker.
+type KubernetesTicker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// KubernetesTimer is the method set of k8s.io/utils/clock.Timer.
+type KubernetesTimer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(duration time.Duration) bool
+}
+
+// KubernetesClock is the method set of k8s.io/utils/clock.WithTickerAndDelayedExecution
+// with the given ticker and timer types.
+type KubernetesClock[Ticker KubernetesTicker, Timer KubernetesTimer] interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(duration time.Duration) <-chan time.Time
+	NewTimer(duration time.Duration) Timer
+	Sleep(duration time.Duration)
+	Tick(duration time.Duration) <-chan time.Time
+	NewTicker(duration time.Duration) Ticker
+	AfterFunc(duration time.Duration, f func()) Timer
+}
+
+// Kubernetes adapts a glock.Clock to k8s.io/utils/clock.WithTickerAndDelayedExecution.
+// The type arguments must be clock.Ticker and clock.Timer.
+type Kubernetes[Ticker KubernetesTicker, Timer KubernetesTimer] struct {
+	clock glock.Clock
+}
+
+var _ KubernetesClock[KubernetesTicker, KubernetesTimer] = &Kubernetes[KubernetesTicker, KubernetesTimer]{}
+
+// ToKubernetes wraps the given clock in a k8s.io/utils/clock.WithTickerAndDelayedExecution.
+func ToKubernetes[Ticker KubernetesTicker, Timer KubernetesTimer](clock glock.Clock) *Kubernetes[Ticker, Timer] {
+	return &Kubernetes[Ticker, Timer]{clock: clock}
+}
+
+func (c *Kubernetes[Ticker, Timer]) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *Kubernetes[Ticker, Timer]) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c *Kubernetes[Ticker, Timer]) After(duration time.Duration) <-chan time.Time {
+	return c.clock.After(duration)
+}
+
+func (c *Kubernetes[Ticker, Timer]) NewTimer(duration time.Duration) Timer {
+	return convert[Timer](timer{c.clock.NewTimer(duration)})
+}
+
+func (c *Kubernetes[Ticker, Timer]) Sleep(duration time.Duration) {
+	c.clock.Sleep(duration)
+}
+
+// Tick returns the channel of a ticker that is never stopped. As with
+// time.Tick, nil is returned for non-positive durations.
+func (c *Kubernetes[Ticker, Timer]) Tick(duration time.Duration) <-chan time.Time {
+	if duration <= 0 {
+		return nil
+	}
+
+	return c.clock.NewTicker(duration).Chan()
+}
+
+func (c *Kubernetes[Ticker, Timer]) NewTicker(duration time.Duration) Ticker {
+	return convert[Ticker](newTicker(c.clock, duration))
+}
+
+func (c *Kubernetes[Ticker, Timer]) AfterFunc(duration time.Duration, f func()) Timer {
+	return convert[Timer](timer{c.clock.AfterFunc(duration, f)})
+}
+
+// fromKubernetes adapts a k8s.io/utils/clock.WithTickerAndDelayedExecution to
+// glock.Clock.
+type fromKubernetes[Ticker KubernetesTicker, Timer KubernetesTimer] struct {
+	clock KubernetesClock[Ticker, Timer]
+}
+
+// FromKubernetes wraps the given k8s.io/utils/clock.WithTickerAndDelayedExecution
+// in a glock.Clock. The type arguments must be clock.Ticker and clock.Timer.
+func FromKubernetes[Ticker KubernetesTicker, Timer KubernetesTimer](clock KubernetesClock[Ticker, Timer]) glock.Clock {
+	return &fromKubernetes[Ticker, Timer]{clock: clock}
+}
+
+func (c *fromKubernetes[Ticker, Timer]) Now() time.Time {
+	return c.clock.Now()
+}
+
+func (c *fromKubernetes[Ticker, Timer]) After(duration time.Duration) <-chan time.Time {
+	return c.clock.After(duration)
+}
+
+func (c *fromKubernetes[Ticker, Timer]) Sleep(duration time.Duration) {
+	c.clock.Sleep(duration)
+}
+
+func (c *fromKubernetes[Ticker, Timer]) Since(t time.Time) time.Duration {
+	return c.clock.Since(t)
+}
+
+func (c *fromKubernetes[Ticker, Timer]) Until(t time.Time) time.Duration {
+	return t.Sub(c.clock.Now())
+}
+
+func (c *fromKubernetes[Ticker, Timer]) NewTicker(duration time.Duration) glock.Ticker {
+	return kubernetesTicker{c.clock.NewTicker(duration)}
+}
+
+func (c *fromKubernetes[Ticker, Timer]) NewTimer(duration time.Duration) glock.Timer {
+	return kubernetesTimer{c.clock.NewTimer(duration)}
+}
+
+func (c *fromKubernetes[Ticker, Timer]) AfterFunc(duration time.Duration, f func()) glock.Timer {
+	return kubernetesTimer{c.clock.AfterFunc(duration, f)}
+}
+
+// kubernetesTicker adapts a k8s.io/utils/clock.Ticker to glock.Ticker.
+type kubernetesTicker struct {
+	KubernetesTicker
+}
+
+func (t kubernetesTicker) Chan() <-chan time.Time {
+	return t.C()
+}
+
+// kubernetesTimer adapts a k8s.io/utils/clock.Timer to glock.Timer.
+type kubernetesTimer struct {
+	KubernetesTimer
+}
+
+func (t kubernetesTimer) Chan() <-chan time.Time {
+	return t.C()
+}
@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/derision-test/glock/glocktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// kubernetesTickerIface and kubernetesTimerIface stand in for the interfaces
+// declared by k8s.io/utils/clock, which the adapters must be instantiated with.
+type (
+	kubernetesTickerIface interface {
+		C() <-chan time.Time
+		Stop()
+	}
+
+	kubernetesTimerIface interface {
+		C() <-chan time.Time
+		Stop() bool
+		Reset(duration time.Duration) bool
+	}
+)
+
+func TestToKubernetes(t *testing.T) {
+	mock := glock.NewMockClockAt(time.Unix(0, 0))
+	clock := ToKubernetes[kubernetesTickerIface, kubernetesTimerIface](mock)
+
+	tick := clock.Tick(time.Second)
+
+	mock.Advance(time.Second)
+	assert.Equal(t, time.Second, clock.Since(time.Unix(0, 0)))
+	assertReceives(t, tick, time.Unix(1, 0))
+	assert.Nil(t, clock.Tick(0))
+}
+
+func TestKubernetesConformance(t *testing.T) {
+	glocktest.RunClockConformance(t, func(t *testing.T) glocktest.Harness {
+		h := glocktest.MockHarness(t)
+		h.Clock = FromKubernetes[kubernetesTickerIface, kubernetesTimerIface](ToKubernetes[kubernetesTickerIface, kubernetesTimerIface](h.Clock))
+		return h
+	})
+}
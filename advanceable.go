@@ -1,6 +1,8 @@
 package glock
 
 import (
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -22,6 +24,46 @@ type advanceable struct {
 	subscribers []subscriber
 	m           *sync.Mutex
 	cond        *sync.Cond
+	tieOrder    *rand.Rand
+
+	// syncCallbacks causes AfterFunc callbacks to be queued in due and run
+	// by the goroutine that moved the time forward (see unlockAndDispatch),
+	// rather than being run in their own goroutines.
+	syncCallbacks bool
+	due           []dueCallback
+
+	// syncSends causes timers and tickers to queue the values they send in
+	// due, so that they are sent by the goroutine that moved the time forward
+	// in event order, rather than from their own goroutines.
+	syncSends bool
+
+	// deferred holds synchronous AfterFunc callbacks that fired as their timer
+	// was created or reset with a deadline that had already passed. They are
+	// run by the next advance or call to WaitForCallbacks rather than before
+	// AfterFunc or Reset returns, as the caller may hold a lock that the
+	// callback takes. The deferring flag is set while such a timer is fired.
+	deferred  []dueCallback
+	deferring bool
+
+	// inFlight counts the AfterFunc callbacks that have fired but not yet
+	// returned. The idle condition is broadcast when it drops to zero.
+	inFlight int
+	idle     *sync.Cond
+
+	// hooks are called with the events of the clock. Calls are queued in
+	// notifications and made by unlockAndDispatch once the lock is released. The
+	// lastID field holds the identifier most recently assigned to a
+	// subscriber.
+	hooks         hooks
+	notifications []func()
+	lastID        uint64
+}
+
+// dueCallback is an AfterFunc callback (or, with syncSends, the send of a timer
+// or ticker value) waiting to be run synchronously.
+type dueCallback struct {
+	deadline time.Time
+	f        func()
 }
 
 type subscriber interface {
@@ -31,6 +73,16 @@ type subscriber interface {
 	// time, it should return true; the clock or timer instance will drop
 	// a reference to this subscriber otherwise.
 	signal(now time.Time) (requeue bool)
+
+	// next returns the time at which the subscriber will next perform its
+	// behavior. If the subscriber is inactive, it should return false.
+	next() (deadline time.Time, ok bool)
+
+	// kind returns the kind of event reported to hooks for the subscriber.
+	kind() EventKind
+
+	// ident returns the identifier reported to hooks for the subscriber.
+	ident() *eventID
 }
 
 // newAdvanceableAt returns a new advanceable struct with the given current time.
@@ -41,6 +93,7 @@ func newAdvanceableAt(now time.Time) *advanceable {
 		now:  now,
 		m:    m,
 		cond: sync.NewCond(m),
+		idle: sync.NewCond(m),
 	}
 }
 
@@ -48,21 +101,34 @@ func newAdvanceableAt(now time.Time) *advanceable {
 func (a *advanceable) Advance(duration time.Duration) {
 	a.m.Lock()
 	a.setCurrent(a.now.Add(duration))
-	a.m.Unlock()
+	a.unlockAndDispatch()
 }
 
 // SetCurrent sets the clock's internal time to the given time.
 func (a *advanceable) SetCurrent(now time.Time) {
 	a.m.Lock()
 	a.setCurrent(now)
-	a.m.Unlock()
+	a.unlockAndDispatch()
 }
 
 // setCurrent sets the new current time and invokes and filters the list of
 // subscribers.
 func (a *advanceable) setCurrent(now time.Time) {
+	if a.tieOrder != nil {
+		a.orderSubscribers()
+	}
+
+	a.notifyAdvance(a.now, now)
+
+	a.due = append(a.due, a.deferred...)
+	a.deferred = nil
+
 	filtered := a.subscribers[:0]
 	for _, e := range a.subscribers {
+		if deadline, ok := e.next(); ok && !now.Before(deadline) {
+			a.notifyFire(e, deadline, now)
+		}
+
 		if e.signal(now) {
 			filtered = append(filtered, e)
 		}
@@ -73,8 +139,134 @@ func (a *advanceable) setCurrent(now time.Time) {
 	a.cond.Broadcast()
 }
 
-// register marks a subscriber to be updated when the current time changes.
+// fireCallback runs an AfterFunc callback that has reached its deadline. In
+// synchronous mode the callback is queued to be run by unlockAndDispatch once
+// the lock is released (or deferred, see the deferred field); otherwise it is
+// run in its own goroutine. This method assumes the lock is held.
+func (a *advanceable) fireCallback(deadline time.Time, f func()) {
+	if a.syncCallbacks {
+		if a.deferring {
+			a.deferred = append(a.deferred, dueCallback{deadline: deadline, f: f})
+		} else {
+			a.due = append(a.due, dueCallback{deadline: deadline, f: f})
+		}
+
+		return
+	}
+
+	a.inFlight++
+	go func() {
+		defer a.callbackDone()
+		f()
+	}()
+}
+
+// queueSend queues the send of a timer or ticker value to be made by
+// unlockAndDispatch once the lock is released (see syncSends). The send waits
+// for a reader, and is abandoned once the given cancel channel is closed. This
+// method assumes the lock is held.
+func (a *advanceable) queueSend(deadline time.Time, ch chan<- time.Time, value time.Time, cancel <-chan struct{}) {
+	a.due = append(a.due, dueCallback{deadline: deadline, f: func() { sendOrCancel(ch, value, cancel) }})
+}
+
+// sendOrCancel sends the given value on ch unless cancel is closed first.
+func sendOrCancel(ch chan<- time.Time, value time.Time, cancel <-chan struct{}) {
+	select {
+	case ch <- value:
+	case <-cancel:
+	}
+}
+
+// callbackDone marks an asynchronous AfterFunc callback as returned.
+func (a *advanceable) callbackDone() {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.inFlight--
+	if a.inFlight == 0 {
+		a.idle.Broadcast()
+	}
+}
+
+// unlockAndDispatch releases the lock, then makes the hook calls queued while
+// the caller held it in order, then runs the AfterFunc callbacks and timer and
+// ticker sends queued while the caller held it in deadline order. The queued work is taken before the lock
+// is released, so it is run by the goroutine whose call produced it and before
+// that call returns, never by an unrelated call. Hooks and callbacks are run
+// without the lock held so that they can call back into the clock; work queued
+// by those calls is run by them. This method assumes the lock is held.
+func (a *advanceable) unlockAndDispatch() {
+	notifications, due := a.notifications, a.due
+	a.notifications, a.due = nil, nil
+	a.m.Unlock()
+
+	for _, f := range notifications {
+		f()
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].deadline.Before(due[j].deadline) })
+
+	for _, c := range due {
+		c.f()
+	}
+}
+
+// orderSubscribers sorts the list of subscribers by deadline and shuffles
+// the subscribers that share a deadline using the tie-order random source.
+// Inactive subscribers are moved to the end of the list.
+func (a *advanceable) orderSubscribers() {
+	deadlines := make(map[subscriber]time.Time, len(a.subscribers))
+	for _, s := range a.subscribers {
+		if deadline, ok := s.next(); ok {
+			deadlines[s] = deadline
+		}
+	}
+
+	sort.SliceStable(a.subscribers, func(i, j int) bool {
+		di, iok := deadlines[a.subscribers[i]]
+		dj, jok := deadlines[a.subscribers[j]]
+		if iok != jok {
+			return iok
+		}
+
+		return di.Before(dj)
+	})
+
+	for i := 0; i < len(a.subscribers); {
+		deadline, ok := deadlines[a.subscribers[i]]
+		if !ok {
+			break
+		}
+
+		j := i + 1
+		for j < len(a.subscribers) && deadlines[a.subscribers[j]].Equal(deadline) {
+			j++
+		}
+
+		group := a.subscribers[i:j]
+		a.tieOrder.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+		i = j
+	}
+}
+
+// nextDeadline returns the earliest deadline of any active subscriber.
+func (a *advanceable) nextDeadline() (time.Time, bool) {
+	var earliest time.Time
+	found := false
+
+	for _, s := range a.subscribers {
+		if deadline, ok := s.next(); ok && (!found || deadline.Before(earliest)) {
+			earliest, found = deadline, true
+		}
+	}
+
+	return earliest, found
+}
+
+// register marks a subscriber to be updated when the current time changes and
+// announces it to hooks.
 func (a *advanceable) register(subscriber subscriber) {
 	a.subscribers = append(a.subscribers, subscriber)
+	a.announce(subscriber)
 	a.cond.Broadcast()
 }
@@ -0,0 +1,132 @@
+// Package backoff provides retry policies and a retry helper whose sleeps
+// are driven by a glock.Clock.
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Attempt describes the state of a retry loop at the point where a policy
+// is asked for the delay before the next call.
+type Attempt struct {
+	// Number is the number of calls made so far. It is one when the policy
+	// is asked for the delay following the first failure.
+	Number int
+
+	// Elapsed is the time elapsed since the retry loop began.
+	Elapsed time.Duration
+
+	// Previous is the delay returned for the preceding attempt, or zero if
+	// this is the first retry.
+	Previous time.Duration
+}
+
+// Policy determines how long to wait between successive attempts.
+type Policy interface {
+	// Next returns the duration to wait before the next attempt. If the
+	// policy decides that no further attempts should be made, it returns
+	// false.
+	Next(attempt Attempt) (delay time.Duration, ok bool)
+}
+
+// PolicyFunc is a function that conforms to the Policy interface.
+type PolicyFunc func(attempt Attempt) (time.Duration, bool)
+
+// Next conforms to the Policy interface.
+func (f PolicyFunc) Next(attempt Attempt) (time.Duration, bool) {
+	return f(attempt)
+}
+
+// Constant returns a policy that waits the same duration between every attempt.
+func Constant(delay time.Duration) Policy {
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		return delay, true
+	})
+}
+
+// Linear returns a policy that waits initial before the first retry and
+// increases the delay by increment on every following retry. The delay never
+// exceeds max unless max is zero, in which case the delay is unbounded.
+func Linear(initial, increment, max time.Duration) Policy {
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		return capDelay(initial+time.Duration(attempt.Number-1)*increment, max), true
+	})
+}
+
+// Exponential returns a policy that waits initial before the first retry and
+// multiplies the delay by multiplier on every following retry. The delay never
+// exceeds max unless max is zero, in which case the delay is unbounded.
+func Exponential(initial time.Duration, multiplier float64, max time.Duration) Policy {
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt.Number-1))
+		if delay >= math.MaxInt64 {
+			return capDelay(math.MaxInt64, max), true
+		}
+
+		return capDelay(time.Duration(delay), max), true
+	})
+}
+
+// DecorrelatedJitter returns a policy that waits a random duration between
+// base and three times the previous delay, capped at max. This is the
+// "decorrelated jitter" strategy, which spreads out retries of many clients
+// that failed at the same moment. The random source is seeded with the given
+// seed so that the sequence of delays is reproducible.
+func DecorrelatedJitter(base, max time.Duration, seed int64) Policy {
+	var m sync.Mutex
+	r := rand.New(rand.NewSource(seed))
+
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		previous := attempt.Previous
+		if previous < base {
+			previous = base
+		}
+
+		upper := 3 * previous
+		if upper <= base {
+			return capDelay(base, max), true
+		}
+
+		m.Lock()
+		delay := base + time.Duration(r.Int63n(int64(upper-base)))
+		m.Unlock()
+
+		return capDelay(delay, max), true
+	})
+}
+
+// WithMaxAttempts wraps the given policy so that no more than the given
+// number of calls (including the first) are made.
+func WithMaxAttempts(policy Policy, maxAttempts int) Policy {
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		if attempt.Number >= maxAttempts {
+			return 0, false
+		}
+
+		return policy.Next(attempt)
+	})
+}
+
+// WithMaxElapsedTime wraps the given policy so that no attempt is scheduled
+// to begin after maxElapsed has passed since the start of the retry loop.
+func WithMaxElapsedTime(policy Policy, maxElapsed time.Duration) Policy {
+	return PolicyFunc(func(attempt Attempt) (time.Duration, bool) {
+		delay, ok := policy.Next(attempt)
+		if !ok || attempt.Elapsed+delay > maxElapsed {
+			return 0, false
+		}
+
+		return delay, true
+	})
+}
+
+func capDelay(delay, max time.Duration) time.Duration {
+	if max > 0 && delay > max {
+		return max
+	}
+
+	return delay
+}
@@ -0,0 +1,90 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("constant", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{
+			time.Second,
+			time.Second,
+			time.Second,
+		}, delays(Constant(time.Second), 3))
+	})
+	t.Run("linear", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{
+			1 * time.Second,
+			3 * time.Second,
+			5 * time.Second,
+			6 * time.Second,
+		}, delays(Linear(time.Second, 2*time.Second, 6*time.Second), 4))
+	})
+	t.Run("exponential", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{
+			1 * time.Second,
+			2 * time.Second,
+			4 * time.Second,
+			8 * time.Second,
+			10 * time.Second,
+		}, delays(Exponential(time.Second, 2, 10*time.Second), 5))
+	})
+	t.Run("exponential does not overflow", func(t *testing.T) {
+		delay, ok := Exponential(time.Second, 10, 0).Next(Attempt{Number: 100})
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(1<<63-1), delay)
+	})
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		d1 := delays(DecorrelatedJitter(time.Second, time.Minute, 42), 10)
+		d2 := delays(DecorrelatedJitter(time.Second, time.Minute, 42), 10)
+		assert.Equal(t, d1, d2, "expected equal seeds to produce equal delays")
+
+		previous := time.Second
+		for _, delay := range d1 {
+			assert.True(t, delay >= time.Second, "delay %s below base", delay)
+			assert.True(t, delay <= time.Minute, "delay %s above max", delay)
+			assert.True(t, delay < 3*previous, "delay %s not within 3x previous delay %s", delay, previous)
+			previous = delay
+		}
+	})
+	t.Run("max attempts", func(t *testing.T) {
+		assert.Equal(t, []time.Duration{
+			time.Second,
+			time.Second,
+		}, delays(WithMaxAttempts(Constant(time.Second), 3), 10))
+	})
+	t.Run("max elapsed time", func(t *testing.T) {
+		policy := WithMaxElapsedTime(Constant(time.Second), 5*time.Second)
+
+		delay, ok := policy.Next(Attempt{Number: 1, Elapsed: 4 * time.Second})
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, delay)
+
+		_, ok = policy.Next(Attempt{Number: 2, Elapsed: 4*time.Second + time.Millisecond})
+		assert.False(t, ok)
+	})
+}
+
+// delays returns the delays produced by the given policy for up to n
+// consecutive attempts, stopping early if the policy gives up.
+func delays(policy Policy, n int) []time.Duration {
+	var delays []time.Duration
+	previous := time.Duration(0)
+
+	for number := 1; number <= n; number++ {
+		delay, ok := policy.Next(Attempt{Number: number, Previous: previous})
+		if !ok {
+			break
+		}
+
+		delays = append(delays, delay)
+		previous = delay
+	}
+
+	return delays
+}
@@ -0,0 +1,66 @@
+package backoff
+
+import (
+	"context"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// Retry calls fn until it returns a nil error, the policy declines to make
+// another attempt, or the context is canceled. The delay between attempts is
+// determined by the given policy and is waited out on a timer created by the
+// given clock, so a mock clock can be used to control (and inspect) the retry
+// schedule.
+//
+// If the policy gives up, the error from the last call to fn is returned. If
+// the context is canceled while waiting between attempts, the context error
+// is returned.
+func Retry(ctx context.Context, clock glock.Clock, policy Policy, fn func(ctx context.Context) error) error {
+	start := clock.Now()
+	previous := time.Duration(0)
+
+	for number := 1; ; number++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		delay, ok := policy.Next(Attempt{
+			Number:   number,
+			Elapsed:  clock.Since(start),
+			Previous: previous,
+		})
+		if !ok {
+			return err
+		}
+
+		if err := sleep(ctx, clock, delay); err != nil {
+			return err
+		}
+
+		previous = delay
+	}
+}
+
+// sleep blocks until the given duration elapses on the given clock or the
+// context is canceled, whichever comes first.
+func sleep(ctx context.Context, clock glock.Clock, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.Chan():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
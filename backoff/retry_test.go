@@ -0,0 +1,116 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("succeeds immediately", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+
+		calls := 0
+		err := Retry(context.Background(), clock, Constant(time.Second), func(ctx context.Context) error {
+			calls++
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, clock.GetTimerArgs())
+	})
+	t.Run("follows schedule", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		policy := Exponential(time.Second, 2, 0)
+
+		calls := 0
+		errs := make(chan error, 1)
+		go func() {
+			errs <- Retry(context.Background(), clock, policy, func(ctx context.Context) error {
+				if calls++; calls < 4 {
+					return errors.New("oops")
+				}
+				return nil
+			})
+		}()
+
+		expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
+		assert.Equal(t, expected, advanceSchedule(t, clock, len(expected)))
+		assert.Nil(t, <-errs)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, time.Unix(7, 0), clock.Now())
+	})
+	t.Run("returns last error when policy gives up", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		policy := WithMaxAttempts(Constant(time.Second), 3)
+
+		calls := 0
+		errs := make(chan error, 1)
+		go func() {
+			errs <- Retry(context.Background(), clock, policy, func(ctx context.Context) error {
+				calls++
+				return errors.New("oops")
+			})
+		}()
+
+		advanceSchedule(t, clock, 2)
+		assert.EqualError(t, <-errs, "oops")
+		assert.Equal(t, 3, calls)
+	})
+	t.Run("honors max elapsed time", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		policy := WithMaxElapsedTime(Constant(2*time.Second), 5*time.Second)
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- Retry(context.Background(), clock, policy, func(ctx context.Context) error {
+				return errors.New("oops")
+			})
+		}()
+
+		expected := []time.Duration{2 * time.Second, 2 * time.Second}
+		assert.Equal(t, expected, advanceSchedule(t, clock, len(expected)))
+		assert.EqualError(t, <-errs, "oops")
+	})
+	t.Run("stops when context is canceled", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errs := make(chan error, 1)
+		go func() {
+			errs <- Retry(ctx, clock, Constant(time.Minute), func(ctx context.Context) error {
+				return errors.New("oops")
+			})
+		}()
+
+		assert.Eventually(t, func() bool { return len(clock.GetTimerArgs()) == 1 }, time.Second, 10*time.Millisecond)
+		cancel()
+		assert.Equal(t, context.Canceled, <-errs)
+	})
+}
+
+// advanceSchedule waits for the retry loop to create n timers in sequence,
+// advancing the clock by each timer's duration once it is created. The
+// durations of the observed timers are returned.
+func advanceSchedule(t *testing.T, clock *glock.MockClock, n int) []time.Duration {
+	var args []time.Duration
+	for i := 0; i < n; i++ {
+		if !assert.Eventually(t, func() bool {
+			args = append(args, clock.GetTimerArgs()...)
+			return len(args) > i
+		}, time.Second, time.Millisecond) {
+			break
+		}
+
+		clock.Advance(args[i])
+	}
+
+	return args
+}
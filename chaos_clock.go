@@ -0,0 +1,338 @@
+package glock
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ChaosConfig configures the faults injected by a ChaosClock. Probabilities
+// are in the range [0, 1]; a zero value disables the corresponding fault.
+type ChaosConfig struct {
+	// Seed seeds the random source that decides when and how faults are
+	// injected, making a faulty run reproducible.
+	Seed int64
+
+	// DelayProbability is the probability that a value delivered by an
+	// After channel, timer, or ticker (or an AfterFunc callback) is delayed.
+	DelayProbability float64
+
+	// MaxDelay bounds the duration of an injected delivery delay.
+	MaxDelay time.Duration
+
+	// DropTickProbability is the probability that a ticker tick is dropped.
+	DropTickProbability float64
+
+	// CoalesceTickProbability is the probability that a ticker tick is held
+	// back and delivered in place of the following tick.
+	CoalesceTickProbability float64
+
+	// JumpProbability is the probability that a read of the current time
+	// is preceded by a wall-clock jump.
+	JumpProbability float64
+
+	// MaxJump bounds the magnitude of a wall-clock jump. Jumps move the
+	// clock forwards or backwards and accumulate over time.
+	MaxJump time.Duration
+
+	// SleepJitter bounds the additional duration added to each Sleep.
+	SleepJitter time.Duration
+}
+
+// ChaosClock is an implementation of Clock that wraps another clock and
+// injects timing faults: delayed timer and ticker deliveries, dropped or
+// coalesced ticks, wall-clock jumps, and jittered sleeps. All faults are
+// decided by a seeded random source. A ChaosClock can be installed in a
+// non-test environment (e.g. via WithContext) to shake out code that makes
+// unfounded assumptions about time.
+type ChaosClock struct {
+	base   Clock
+	config ChaosConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+	offset time.Duration
+}
+
+var _ Clock = &ChaosClock{}
+
+// NewChaosClock creates a new ChaosClock wrapping the given clock.
+func NewChaosClock(base Clock, config ChaosConfig) *ChaosClock {
+	return &ChaosClock{
+		base:   base,
+		config: config,
+		rand:   rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// Offset returns the total wall-clock jump injected so far.
+func (c *ChaosClock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.offset
+}
+
+// Now returns the underlying clock's current time shifted by the injected
+// wall-clock jumps.
+func (c *ChaosClock) Now() time.Time {
+	c.mu.Lock()
+	if c.chance(c.config.JumpProbability) {
+		c.offset += c.between(-c.config.MaxJump, c.config.MaxJump)
+	}
+	offset := c.offset
+	c.mu.Unlock()
+
+	return c.base.Now().Add(offset)
+}
+
+// skewedNow returns the underlying clock's current time shifted by the jumps
+// injected so far, without injecting another. Timers and tickers deliver this
+// time so that firing does not consume the random source.
+func (c *ChaosClock) skewedNow() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.base.Now().Add(c.offset)
+}
+
+// After returns a channel that receives the current time after the given
+// duration elapses, possibly with an injected delay.
+func (c *ChaosClock) After(duration time.Duration) <-chan time.Time {
+	return c.NewTimer(duration).Chan()
+}
+
+// Sleep blocks for the given duration plus an injected jitter.
+func (c *ChaosClock) Sleep(duration time.Duration) {
+	c.mu.Lock()
+	jitter := c.between(0, c.config.SleepJitter)
+	c.mu.Unlock()
+
+	c.base.Sleep(duration + jitter)
+}
+
+// Since returns the time elapsed since t, as read by Now.
+func (c *ChaosClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, as read by Now.
+func (c *ChaosClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// NewTicker creates a new Ticker on the underlying clock whose ticks may be
+// delayed, dropped, or coalesced. Ticks are shifted by the wall-clock jumps
+// injected so far.
+func (c *ChaosClock) NewTicker(duration time.Duration) Ticker {
+	t := &chaosTicker{
+		clock:  c,
+		ticker: c.base.NewTicker(duration),
+		ch:     make(chan time.Time, 1),
+		done:   make(chan struct{}),
+	}
+
+	go t.process()
+	return t
+}
+
+// NewTimer creates a new Timer on the underlying clock whose delivery may
+// be delayed. The timer receives the underlying clock's time shifted by the
+// wall-clock jumps injected so far.
+func (c *ChaosClock) NewTimer(duration time.Duration) Timer {
+	ch := make(chan time.Time, 1)
+
+	return c.newTimer(duration, ch, func() {
+		select {
+		case ch <- c.skewedNow():
+		default:
+		}
+	})
+}
+
+// AfterFunc creates a new Timer on the underlying clock that calls f in its
+// own goroutine, possibly after an injected delay.
+func (c *ChaosClock) AfterFunc(duration time.Duration, f func()) Timer {
+	return c.newTimer(duration, nil, f)
+}
+
+func (c *ChaosClock) newTimer(duration time.Duration, ch chan time.Time, deliver func()) Timer {
+	t := &chaosTimer{clock: c, ch: ch, deliver: deliver}
+	t.arm(duration)
+	return t
+}
+
+// delay returns an injected delivery delay, or zero if no delay should be
+// injected.
+func (c *ChaosClock) delay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.chance(c.config.DelayProbability) {
+		return 0
+	}
+
+	return c.between(0, c.config.MaxDelay)
+}
+
+// chance returns true with the given probability. This method assumes the
+// lock is held.
+func (c *ChaosClock) chance(probability float64) bool {
+	return probability > 0 && c.rand.Float64() < probability
+}
+
+// between returns a random duration in [min, max]. This method assumes the
+// lock is held.
+func (c *ChaosClock) between(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+
+	return min + time.Duration(c.rand.Int63n(int64(max-min)+1))
+}
+
+// chaosTimer arms a new timer on the underlying clock each time it is reset.
+// Every arm is tagged with a generation that Reset and Stop increment, so an
+// arm that fired but has not yet delivered (or scheduled a delayed delivery)
+// when the timer is reset or stopped delivers nothing.
+type chaosTimer struct {
+	clock      *ChaosClock
+	ch         chan time.Time
+	deliver    func()
+	mu         sync.Mutex
+	generation int
+	timer      Timer
+	delayed    Timer
+}
+
+func (t *chaosTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *chaosTimer) Reset(duration time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := t.stop()
+	t.arm(duration)
+	return active
+}
+
+func (t *chaosTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stop()
+}
+
+// arm starts a new generation of the timer that fires after the given
+// duration. This method assumes the lock is held (or that the timer has not
+// yet been shared).
+func (t *chaosTimer) arm(duration time.Duration) {
+	generation := t.generation
+	t.timer = t.clock.base.AfterFunc(duration, func() { t.fire(generation) })
+}
+
+// stop invalidates the current generation and cancels its pending or delayed
+// delivery, if any. This method assumes the lock is held.
+func (t *chaosTimer) stop() bool {
+	t.generation++
+
+	stopped := t.timer.Stop()
+	if t.delayed != nil {
+		stopped = t.delayed.Stop() || stopped
+		t.delayed = nil
+	}
+
+	return stopped
+}
+
+// fire delivers the timer's value for the given generation, possibly after
+// an injected delay.
+func (t *chaosTimer) fire(generation int) {
+	delay := t.clock.delay()
+
+	t.mu.Lock()
+	if generation != t.generation {
+		t.mu.Unlock()
+		return
+	}
+
+	if delay > 0 {
+		t.delayed = t.clock.base.AfterFunc(delay, func() { t.fireDelayed(generation) })
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
+
+	t.deliver()
+}
+
+// fireDelayed delivers the timer's value for the given generation once its
+// injected delay has elapsed.
+func (t *chaosTimer) fireDelayed(generation int) {
+	t.mu.Lock()
+	if generation != t.generation {
+		t.mu.Unlock()
+		return
+	}
+
+	t.delayed = nil
+	t.mu.Unlock()
+
+	t.deliver()
+}
+
+type chaosTicker struct {
+	clock  *ChaosClock
+	ticker Ticker
+	ch     chan time.Time
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (t *chaosTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *chaosTicker) Stop() {
+	t.once.Do(func() {
+		t.ticker.Stop()
+		close(t.done)
+	})
+}
+
+func (t *chaosTicker) process() {
+	var held *time.Time
+
+	forwardLatestTick(t.ticker.Chan(), t.ch, t.done, func(now time.Time) (time.Time, bool) {
+		t.clock.mu.Lock()
+		drop := t.clock.chance(t.clock.config.DropTickProbability)
+		coalesce := !drop && held == nil && t.clock.chance(t.clock.config.CoalesceTickProbability)
+		t.clock.mu.Unlock()
+
+		if drop {
+			return time.Time{}, false
+		}
+		if coalesce {
+			held = &now
+			return time.Time{}, false
+		}
+		if held != nil {
+			now, held = *held, nil
+		}
+
+		if delay := t.clock.delay(); delay > 0 {
+			select {
+			case <-t.clock.base.After(delay):
+			case <-t.done:
+				return time.Time{}, false
+			}
+		}
+
+		t.clock.mu.Lock()
+		offset := t.clock.offset
+		t.clock.mu.Unlock()
+
+		return now.Add(offset), true
+	}, nil)
+}
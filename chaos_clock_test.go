@@ -0,0 +1,162 @@
+package glock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaosClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no faults", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{})
+
+		assert.Equal(t, time.Unix(0, 0), clock.Now())
+
+		timer := clock.NewTimer(time.Second)
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(1, 0)))
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+	})
+	t.Run("wall-clock jumps", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(100, 0))
+		config := ChaosConfig{Seed: 42, JumpProbability: 1, MaxJump: time.Minute}
+		c1 := NewChaosClock(mock, config)
+		c2 := NewChaosClock(mock, config)
+
+		for i := 0; i < 20; i++ {
+			now := c1.Now()
+			assert.Equal(t, mock.Now().Add(c1.Offset()), now)
+			assert.Equal(t, now, c2.Now(), "expected equal seeds to produce equal jumps")
+		}
+
+		assert.NotEqual(t, time.Duration(0), c1.Offset())
+		assert.True(t, c1.Offset() <= 20*time.Minute && c1.Offset() >= -20*time.Minute)
+	})
+	t.Run("delayed timer", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{Seed: 1, DelayProbability: 1, MaxDelay: time.Second})
+
+		ch := clock.After(time.Second)
+		mock.Advance(time.Second)
+		var args []time.Duration
+		eventually(t, func() bool {
+			args = append(args, mock.GetTimerArgs()...)
+			return len(args) == 2
+		})
+		consistently(t, chanDoesNotReceive(ch))
+
+		mock.Advance(time.Second)
+		eventually(t, func() bool { return len(ch) == 1 })
+	})
+	t.Run("stop cancels delayed delivery", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{Seed: 1, DelayProbability: 1, MaxDelay: time.Second})
+
+		timer := clock.NewTimer(time.Second)
+		mock.Advance(time.Second)
+		var args []time.Duration
+		eventually(t, func() bool {
+			args = append(args, mock.GetTimerArgs()...)
+			return len(args) == 2
+		})
+
+		assert.True(t, timer.Stop())
+		mock.Advance(time.Second)
+		consistently(t, chanDoesNotReceive(timer.Chan()))
+	})
+	t.Run("reset between fire and delayed delivery", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{Seed: 1, DelayProbability: 1, MaxDelay: time.Second})
+
+		var calls int32
+		timer := clock.AfterFunc(time.Second, func() { atomic.AddInt32(&calls, 1) })
+
+		// Hold the clock's lock so that the fired timer cannot decide on
+		// its delivery delay until after the reset
+		clock.mu.Lock()
+		mock.Advance(time.Second)
+		assert.False(t, timer.Reset(time.Second))
+		clock.mu.Unlock()
+
+		for i := 0; i < 4; i++ {
+			mock.WaitForCallbacks()
+			mock.Advance(time.Second)
+		}
+		mock.WaitForCallbacks()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+	t.Run("timer and ticker values match the skewed clock", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{Seed: 3, JumpProbability: 1, MaxJump: time.Minute})
+
+		clock.Now()
+		offset := clock.Offset()
+		assert.NotEqual(t, time.Duration(0), offset)
+
+		timer := clock.NewTimer(time.Second)
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(1, 0).Add(offset)))
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0).Add(offset)))
+		assert.Equal(t, offset, clock.Offset(), "expected firing not to inject a jump")
+	})
+	t.Run("dropped ticks", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{DropTickProbability: 1})
+
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for i := 0; i < 3; i++ {
+			mock.Advance(time.Second)
+			consistently(t, chanDoesNotReceive(ticker.Chan()))
+		}
+	})
+	t.Run("coalesced ticks", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{CoalesceTickProbability: 1})
+
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		mock.Advance(time.Second)
+		consistently(t, chanDoesNotReceive(ticker.Chan()))
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+		mock.Advance(time.Second)
+		consistently(t, chanDoesNotReceive(ticker.Chan()))
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(3, 0)))
+	})
+	t.Run("sleep jitter", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		clock := NewChaosClock(mock, ChaosConfig{Seed: 7, SleepJitter: time.Second})
+
+		done := make(chan struct{})
+		go func() {
+			clock.Sleep(time.Second)
+			close(done)
+		}()
+
+		var args []time.Duration
+		eventually(t, func() bool {
+			args = append(args, mock.GetAfterArgs()...)
+			return len(args) == 1
+		})
+		assert.True(t, args[0] >= time.Second && args[0] <= 2*time.Second, "unexpected sleep duration %s", args[0])
+
+		mock.Advance(2 * time.Second)
+		eventually(t, structChanReceives(done))
+	})
+}
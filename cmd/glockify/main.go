@@ -0,0 +1,106 @@
+// Command glockify migrates packages from the time package to glock. For each
+// package matching the given patterns, it
+//
+//   - adds a clock glock.Clock field to structs whose methods use the time package
+//     and initializes it with a real clock in composite literals,
+//   - replaces calls to time functions in those methods with calls on the clock,
+//   - rewrites *time.Ticker and *time.Timer fields that are only used through the
+//     glock interfaces to glock.Ticker and glock.Timer (and reads of their C field
+//     to calls to Chan), and
+//   - replaces context.WithTimeout and context.WithDeadline with
+//     glock.ContextWithTimeout and glock.ContextWithDeadline.
+//
+// Uses of the time package that cannot be migrated automatically (such as calls
+// in functions without a receiver, calls in scopes that shadow the receiver, and
+// tickers, timers, and fields used as a *time.Ticker or *time.Timer) are
+// reported on standard error.
+//
+// Usage:
+//
+//	glockify [-w] [-l] [packages]
+//
+// By default, the rewritten files are printed to standard output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	write = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	list  = flag.Bool("l", false, "list files whose source would be rewritten")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: glockify [-w] [-l] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	if err := run(patterns); err != nil {
+		fmt.Fprintf(os.Stderr, "glockify: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string) error {
+	config := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedFiles |
+			packages.NeedSyntax |
+			packages.NeedImports |
+			packages.NeedDeps |
+			packages.NeedTypes |
+			packages.NeedTypesInfo,
+	}
+
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+
+	for _, pkg := range pkgs {
+		files, warnings, err := Rewrite(&Package{
+			Fset:  pkg.Fset,
+			Files: pkg.Syntax,
+			Types: pkg.Types,
+			Info:  pkg.TypesInfo,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range warnings {
+			fmt.Fprintln(os.Stderr, warning)
+		}
+
+		for _, file := range files {
+			if *list {
+				fmt.Println(file.Name)
+			}
+
+			if *write {
+				if err := os.WriteFile(file.Name, file.Source, 0o644); err != nil {
+					return err
+				}
+			} else if !*list {
+				os.Stdout.Write(file.Source)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,991 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	glockPath = "github.com/derision-test/glock"
+
+	// clockField is the name of the field added to structs whose methods
+	// use the time package.
+	clockField = "clock"
+)
+
+// clockMethods is the set of time package functions that have an equivalent
+// Clock method of the same name.
+var clockMethods = map[string]struct{}{
+	"Now":       {},
+	"Sleep":     {},
+	"After":     {},
+	"NewTimer":  {},
+	"NewTicker": {},
+	"AfterFunc": {},
+	"Since":     {},
+	"Until":     {},
+}
+
+// contextFunctions maps the context package functions that have an equivalent
+// glock function to the name of that function.
+var contextFunctions = map[string]string{
+	"WithTimeout":  "ContextWithTimeout",
+	"WithDeadline": "ContextWithDeadline",
+}
+
+// glockTypes maps the time package types that are replaced by a glock interface
+// (when referenced by pointer) to the name of that interface.
+var glockTypes = map[string]string{
+	"Ticker": "Ticker",
+	"Timer":  "Timer",
+}
+
+// createFunctions maps the time package functions that create a ticker or timer
+// to the fields and methods of the result that can be used through the glock
+// interface that replaces it. The C field is replaced by the Chan method.
+var createFunctions = map[string]map[string]bool{
+	"NewTicker": {"C": true, "Stop": true},
+	"NewTimer":  {"C": true, "Stop": true, "Reset": true},
+	"AfterFunc": {"C": true, "Stop": true, "Reset": true},
+}
+
+// Package is a type-checked package to be rewritten.
+type Package struct {
+	Fset  *token.FileSet
+	Files []*ast.File
+	Types *types.Package
+	Info  *types.Info
+}
+
+// File is the rewritten source of a file.
+type File struct {
+	Name   string
+	Source []byte
+}
+
+// Warning describes a use of the time package that could not be rewritten
+// automatically.
+type Warning struct {
+	Pos     token.Position
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Pos, w.Message)
+}
+
+// edit replaces the source text in [pos, end) with text.
+type edit struct {
+	pos, end token.Pos
+	text     string
+}
+
+// rewriter holds the state of a single package rewrite.
+type rewriter struct {
+	pkg      *Package
+	edits    map[*ast.File][]edit
+	warnings []Warning
+
+	// structs maps struct types whose methods use the time package to the
+	// name of the field holding their clock.
+	structs map[*types.TypeName]string
+
+	// fields is the set of *time.Ticker and *time.Timer struct fields whose
+	// type is rewritten to the equivalent glock interface.
+	fields map[*types.Var]struct{}
+
+	// vars is the set of variables initialized by a rewritten call to
+	// time.NewTicker, time.NewTimer, or time.AfterFunc.
+	vars map[*types.Var]struct{}
+
+	// calls is the set of rewritten calls to time.NewTicker, time.NewTimer,
+	// or time.AfterFunc whose result is used directly.
+	calls map[*ast.CallExpr]struct{}
+}
+
+// Rewrite migrates the given package to glock. It returns the new source of each
+// file that was modified along with the uses of the time package that need to be
+// migrated by hand. The package's files are read from disk.
+func Rewrite(pkg *Package) ([]File, []Warning, error) {
+	r := &rewriter{
+		pkg:     pkg,
+		edits:   map[*ast.File][]edit{},
+		structs: map[*types.TypeName]string{},
+		fields:  map[*types.Var]struct{}{},
+		vars:    map[*types.Var]struct{}{},
+		calls:   map[*ast.CallExpr]struct{}{},
+	}
+
+	for _, file := range pkg.Files {
+		r.collectStructs(file)
+	}
+	for _, file := range pkg.Files {
+		r.rewriteStructs(file)
+	}
+	for _, file := range pkg.Files {
+		r.rewriteCalls(file)
+	}
+	for _, file := range pkg.Files {
+		r.rewriteLiterals(file)
+		r.warnZeroValues(file)
+		r.rewriteChannels(file)
+	}
+
+	var files []File
+	for _, file := range pkg.Files {
+		edits, ok := r.edits[file]
+		if !ok {
+			continue
+		}
+
+		name := pkg.Fset.File(file.Pos()).Name()
+		source, err := r.apply(name, edits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		files = append(files, File{Name: name, Source: source})
+	}
+
+	return files, r.warnings, nil
+}
+
+// collectStructs marks the struct types that have a method using the time
+// package or that have a *time.Ticker or *time.Timer field.
+func (r *rewriter) collectStructs(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil || decl.Body == nil || !r.usesTime(decl.Body) {
+				continue
+			}
+
+			if obj := r.receiverType(decl); obj != nil {
+				r.markStruct(obj)
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				spec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				for _, field := range st.Fields.List {
+					if _, ok := r.timeType(field.Type); ok {
+						r.markStruct(r.pkg.Info.Defs[spec.Name].(*types.TypeName))
+					}
+				}
+			}
+		}
+	}
+}
+
+// markStruct records that the given struct type needs a clock field. An
+// existing glock.Clock field is reused.
+func (r *rewriter) markStruct(obj *types.TypeName) {
+	if _, ok := r.structs[obj]; ok {
+		return
+	}
+
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if field := st.Field(i); isGlockType(field.Type(), "Clock") {
+			r.structs[obj] = field.Name()
+			return
+		}
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if field := st.Field(i); field.Name() == clockField {
+			r.warn(field.Pos(), "struct %s already has a field named %s; add a glock.Clock field by hand", obj.Name(), clockField)
+			r.structs[obj] = ""
+			return
+		}
+	}
+
+	r.structs[obj] = clockField
+}
+
+// rewriteStructs adds clock fields to marked structs and rewrites the type of
+// *time.Ticker and *time.Timer fields.
+func (r *rewriter) rewriteStructs(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+
+		obj, _ := r.pkg.Info.Defs[spec.Name].(*types.TypeName)
+		name := r.structs[obj]
+		if name == "" {
+			return true
+		}
+
+		for _, field := range st.Fields.List {
+			typeName, ok := r.timeType(field.Type)
+			if !ok {
+				continue
+			}
+
+			if !r.fieldsFit(obj.Type().Underlying().(*types.Struct), field, typeName) {
+				r.warn(field.Pos(), "field %s is used as a *time.%s; migrate it to glock.%s by hand", fieldNames(field), typeName, typeName)
+				continue
+			}
+
+			r.replace(file, field.Type, "glock."+typeName)
+			for _, ident := range field.Names {
+				r.fields[r.pkg.Info.Defs[ident].(*types.Var)] = struct{}{}
+			}
+		}
+
+		if name == clockField && !r.hasGlockClock(obj) {
+			if obj.Exported() {
+				r.warn(spec.Name.Pos(), "struct %s is exported; values created outside this package must set its %s field", obj.Name(), clockField)
+			}
+
+			last := st.Fields.Opening
+			if n := len(st.Fields.List); n > 0 {
+				last = st.Fields.List[n-1].End()
+			}
+
+			if r.line(st.Fields.Closing) > r.line(last) {
+				r.insert(file, st.Fields.Closing, clockField+" glock.Clock\n")
+			} else if len(st.Fields.List) > 0 {
+				r.insert(file, last, "; "+clockField+" glock.Clock")
+			} else {
+				r.insert(file, st.Fields.Closing, clockField+" glock.Clock")
+			}
+		}
+
+		return false
+	})
+}
+
+// fieldsFit returns true if every use of the given *time.Ticker or *time.Timer
+// fields in the package can be made through the named glock interface. Each
+// field must only select a member of the interface, be compared with nil, or
+// be set to nil or to the result of a call creating a value of its type.
+func (r *rewriter) fieldsFit(st *types.Struct, field *ast.Field, typeName string) bool {
+	if len(field.Names) == 0 {
+		// Embedded fields promote the methods of the time package type
+		return false
+	}
+
+	// Map each field to its index for positional composite literals
+	fields := map[types.Object]int{}
+	for _, ident := range field.Names {
+		for i := 0; i < st.NumFields(); i++ {
+			if st.Field(i) == r.pkg.Info.Defs[ident] {
+				fields[st.Field(i)] = i
+			}
+		}
+	}
+
+	members := createFunctions["New"+typeName]
+	fits := true
+
+	for _, file := range r.pkg.Files {
+		var stack []ast.Node
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+			stack = append(stack, n)
+
+			switch n := n.(type) {
+			case *ast.Ident:
+				if _, ok := fields[r.pkg.Info.Uses[n]]; ok && !r.fieldUseFits(stack, typeName, members) {
+					fits = false
+				}
+
+			case *ast.CompositeLit:
+				if len(n.Elts) == 0 {
+					break
+				}
+				if _, keyed := n.Elts[0].(*ast.KeyValueExpr); keyed {
+					break
+				}
+
+				if !types.Identical(r.pkg.Info.TypeOf(n).Underlying(), st) {
+					break
+				}
+
+				for _, i := range fields {
+					if i < len(n.Elts) && !r.createdValue(n.Elts[i], typeName) {
+						fits = false
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	return fits
+}
+
+// fieldUseFits returns true if the field use identified by the last node of the
+// given stack can be made through the glock interface replacing its type.
+func (r *rewriter) fieldUseFits(stack []ast.Node, typeName string, members map[string]bool) bool {
+	ident := stack[len(stack)-1]
+	parent := stack[len(stack)-2]
+
+	if kv, ok := parent.(*ast.KeyValueExpr); ok && kv.Key == ident {
+		return r.createdValue(kv.Value, typeName)
+	}
+
+	sel, ok := parent.(*ast.SelectorExpr)
+	if !ok || sel.Sel != ident {
+		return false
+	}
+
+	switch grandparent := stack[len(stack)-3].(type) {
+	case *ast.SelectorExpr:
+		return members[grandparent.Sel.Name]
+
+	case *ast.BinaryExpr:
+		if grandparent.Op == token.EQL || grandparent.Op == token.NEQ {
+			return r.isNil(grandparent.X) || r.isNil(grandparent.Y)
+		}
+
+	case *ast.AssignStmt:
+		if grandparent.Tok == token.ASSIGN && len(grandparent.Lhs) == len(grandparent.Rhs) {
+			for i, lhs := range grandparent.Lhs {
+				if lhs == sel {
+					return r.createdValue(grandparent.Rhs[i], typeName)
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// createdValue returns true if the given expression is nil or a call to a time
+// function that creates a value of the named type.
+func (r *rewriter) createdValue(expr ast.Expr, typeName string) bool {
+	if r.isNil(expr) {
+		return true
+	}
+
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	pkgPath, name, ok := r.calledFunction(call)
+	if !ok || pkgPath != "time" {
+		return false
+	}
+
+	_, created := createFunctions[name]
+	return created && createdType(name) == typeName
+}
+
+// isNil returns true if the given expression is the predeclared nil.
+func (r *rewriter) isNil(expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, ok = r.pkg.Info.Uses[ident].(*types.Nil)
+	return ok
+}
+
+// rewriteCalls replaces calls to time and context functions within methods of
+// marked structs with the equivalent calls on the struct's clock. Calls where
+// the receiver is shadowed, and calls creating a ticker or timer that is used
+// as a *time.Ticker or *time.Timer, are left in place with a warning.
+func (r *rewriter) rewriteCalls(file *ast.File) {
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.FuncDecl)
+		if !ok || decl.Body == nil {
+			continue
+		}
+
+		recv := r.methodReceiver(file, decl)
+
+		astutil.Apply(decl.Body, nil, func(c *astutil.Cursor) bool {
+			call, ok := c.Node().(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			pkgPath, name, ok := r.calledFunction(call)
+			if !ok {
+				return true
+			}
+
+			if recv == nil {
+				if pkgPath == "time" && r.assignedToField(c) {
+					// Keep the value assignable to the rewritten field
+					r.replace(file, call.Fun, "glock.NewRealClock()."+name)
+					r.warn(call.Pos(), "call to time.%s is not in a method of a struct; replaced with a real clock, pass a glock.Clock by hand", name)
+					return true
+				}
+
+				r.warn(call.Pos(), "call to %s.%s is not in a method of a struct; pass a glock.Clock by hand", pkgPath, name)
+				return true
+			}
+
+			if r.shadowed(recv, call.Pos()) {
+				r.warn(call.Pos(), "call to %s.%s is in a scope where the receiver %s is shadowed; pass a glock.Clock by hand", pkgPath, name, recv.name)
+				return true
+			}
+
+			switch pkgPath {
+			case "time":
+				if members, ok := createFunctions[name]; ok && !r.recordCreated(c, call, decl.Body, members) {
+					r.warn(call.Pos(), "result of time.%s is used as a *time.%s; migrate it to glock.%s by hand", name, createdType(name), createdType(name))
+					return true
+				}
+
+				r.replace(file, call.Fun, recv.clock()+"."+name)
+
+			case "context":
+				r.replace(file, call.Fun, "glock."+contextFunctions[name])
+				r.insert(file, call.Args[0].End(), ", "+recv.clock())
+			}
+
+			return true
+		})
+	}
+}
+
+// assignedToField returns true if the call at the given cursor is assigned to a
+// ticker or timer field whose type was rewritten to a glock interface.
+func (r *rewriter) assignedToField(c *astutil.Cursor) bool {
+	switch parent := c.Parent().(type) {
+	case *ast.KeyValueExpr:
+		if key, ok := parent.Key.(*ast.Ident); ok && parent.Value == c.Node() {
+			v, ok := r.pkg.Info.Uses[key].(*types.Var)
+			if !ok {
+				return false
+			}
+
+			_, ok = r.fields[v]
+			return ok
+		}
+
+	case *ast.AssignStmt:
+		if len(parent.Lhs) == len(parent.Rhs) {
+			for i, rhs := range parent.Rhs {
+				if rhs == c.Node() {
+					return r.rewrittenTimeValue(parent.Lhs[i])
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// recordCreated returns true if the result of the call at the given cursor, which
+// creates a ticker or timer, can be replaced by the equivalent glock interface.
+// This is the case if the result is discarded, assigned to a rewritten field,
+// or only used by selecting one of the given members, either directly or
+// through a variable declared by the enclosing statement. Such variables are
+// recorded so that their uses of the C field can be rewritten.
+func (r *rewriter) recordCreated(c *astutil.Cursor, call *ast.CallExpr, body *ast.BlockStmt, members map[string]bool) bool {
+	var name *ast.Ident
+	switch parent := c.Parent().(type) {
+	case *ast.ExprStmt:
+		return true
+
+	case *ast.SelectorExpr:
+		if !members[parent.Sel.Name] {
+			return false
+		}
+
+		r.calls[call] = struct{}{}
+		return true
+
+	case *ast.AssignStmt:
+		if r.assignedToField(c) {
+			return true
+		}
+
+		if parent.Tok == token.DEFINE && len(parent.Lhs) == len(parent.Rhs) {
+			for i, rhs := range parent.Rhs {
+				if rhs == call {
+					name, _ = parent.Lhs[i].(*ast.Ident)
+				}
+			}
+		}
+
+	case *ast.KeyValueExpr:
+		return r.assignedToField(c)
+
+	case *ast.ValueSpec:
+		if parent.Type == nil && len(parent.Names) == len(parent.Values) {
+			for i, value := range parent.Values {
+				if value == call {
+					name = parent.Names[i]
+				}
+			}
+		}
+	}
+
+	if name == nil {
+		return false
+	}
+	if name.Name == "_" {
+		return true
+	}
+
+	// Short variable declarations may assign to an existing variable
+	v, ok := r.pkg.Info.Defs[name].(*types.Var)
+	if !ok || !r.onlySelected(v, body, members) {
+		return false
+	}
+
+	r.vars[v] = struct{}{}
+	return true
+}
+
+// onlySelected returns true if every use of the given local variable within the
+// given body selects one of the given members.
+func (r *rewriter) onlySelected(v *types.Var, body *ast.BlockStmt, members map[string]bool) bool {
+	uses := 0
+	for _, obj := range r.pkg.Info.Uses {
+		if obj == v {
+			uses++
+		}
+	}
+
+	selected := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if ident, ok := sel.X.(*ast.Ident); ok && r.pkg.Info.Uses[ident] == v && members[sel.Sel.Name] {
+			selected++
+		}
+
+		return true
+	})
+
+	return selected == uses
+}
+
+// rewriteLiterals initializes the clock field of marked structs in composite
+// literals with a real clock.
+func (r *rewriter) rewriteLiterals(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		named, ok := r.pkg.Info.TypeOf(lit).(*types.Named)
+		if !ok {
+			return true
+		}
+
+		if name := r.structs[named.Obj()]; name != clockField || r.hasGlockClock(named.Obj()) {
+			return true
+		}
+
+		const realClock = "glock.NewRealClock()"
+
+		if len(lit.Elts) == 0 {
+			r.insert(file, lit.Rbrace, clockField+": "+realClock)
+			return true
+		}
+
+		last := lit.Elts[len(lit.Elts)-1]
+
+		if _, keyed := last.(*ast.KeyValueExpr); !keyed {
+			// Positional literals set every field; the clock field is last
+			r.insert(file, last.End(), ", "+realClock)
+		} else if r.line(lit.Rbrace) > r.line(last.End()) {
+			// Multi-line literals already have a trailing comma
+			r.insert(file, lit.Rbrace, clockField+": "+realClock+",\n")
+		} else {
+			r.insert(file, last.End(), ", "+clockField+": "+realClock)
+		}
+
+		return true
+	})
+}
+
+// warnZeroValues warns about values of marked structs that are created without
+// a composite literal, as their added clock field is left nil.
+func (r *rewriter) warnZeroValues(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			ident, ok := n.Fun.(*ast.Ident)
+			if !ok || ident.Name != "new" || len(n.Args) != 1 {
+				return true
+			}
+			if _, ok := r.pkg.Info.Uses[ident].(*types.Builtin); !ok {
+				return true
+			}
+
+			if obj, ok := r.addedClock(n.Args[0]); ok {
+				r.warn(n.Pos(), "new(%s) leaves its %s field nil; initialize it by hand", obj.Name(), clockField)
+			}
+
+		case *ast.ValueSpec:
+			if n.Type == nil || len(n.Values) > 0 {
+				return true
+			}
+
+			if obj, ok := r.addedClock(n.Type); ok {
+				r.warn(n.Pos(), "zero value of %s leaves its %s field nil; initialize it by hand", obj.Name(), clockField)
+			}
+		}
+
+		return true
+	})
+}
+
+// addedClock returns the marked struct type denoted by the given expression if
+// a clock field is added to it.
+func (r *rewriter) addedClock(expr ast.Expr) (*types.TypeName, bool) {
+	named, ok := r.pkg.Info.TypeOf(expr).(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	obj := named.Obj()
+	if r.structs[obj] != clockField || r.hasGlockClock(obj) {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+// rewriteChannels replaces reads of the C field of rewritten tickers and timers
+// with calls to Chan.
+func (r *rewriter) rewriteChannels(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if ok && sel.Sel.Name == "C" && r.rewrittenTimeValue(sel.X) {
+			r.replace(file, sel.Sel, "Chan()")
+		}
+
+		return true
+	})
+}
+
+// rewrittenTimeValue returns true if the given expression is a ticker or timer
+// whose type was rewritten to a glock interface.
+func (r *rewriter) rewrittenTimeValue(expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		_, ok := r.calls[expr]
+		return ok
+
+	case *ast.Ident:
+		v, ok := r.pkg.Info.Uses[expr].(*types.Var)
+		if !ok {
+			return false
+		}
+
+		_, ok = r.vars[v]
+		return ok
+
+	case *ast.SelectorExpr:
+		selection, ok := r.pkg.Info.Selections[expr]
+		if !ok || selection.Kind() != types.FieldVal {
+			return false
+		}
+
+		_, ok = r.fields[selection.Obj().(*types.Var)]
+		return ok
+	}
+
+	return false
+}
+
+// receiver is the receiver of a method whose calls are rewritten to use the
+// clock of the receiver's struct.
+type receiver struct {
+	// name is the name of the receiver. The obj field is its variable, or nil
+	// if the receiver is unnamed and is given the name once its clock is used.
+	name string
+	obj  types.Object
+
+	field   string
+	addName func()
+}
+
+// clock returns an expression that evaluates to the receiver's clock.
+func (recv *receiver) clock() string {
+	if recv.addName != nil {
+		recv.addName()
+		recv.addName = nil
+	}
+
+	return recv.name + "." + recv.field
+}
+
+// methodReceiver returns the receiver of the given method. If the method's
+// receiver is unnamed, it is named on first use of its clock. Nil is returned
+// for functions and for methods of unmarked types.
+func (r *rewriter) methodReceiver(file *ast.File, decl *ast.FuncDecl) *receiver {
+	if decl.Recv == nil {
+		return nil
+	}
+
+	obj := r.receiverType(decl)
+	name := r.structs[obj]
+	if obj == nil || name == "" || !r.usesTime(decl.Body) {
+		return nil
+	}
+
+	field := decl.Recv.List[0]
+	if len(field.Names) == 0 {
+		recv := receiverName(obj.Name())
+		return &receiver{name: recv, field: name, addName: func() { r.insert(file, field.Type.Pos(), recv+" ") }}
+	}
+
+	if field.Names[0].Name == "_" {
+		recv := receiverName(obj.Name())
+		return &receiver{name: recv, field: name, addName: func() { r.replace(file, field.Names[0], recv) }}
+	}
+
+	return &receiver{name: field.Names[0].Name, obj: r.pkg.Info.Defs[field.Names[0]], field: name}
+}
+
+// shadowed returns true if the name of the given receiver does not refer to the
+// receiver at the given position. The name of an unnamed receiver must not
+// refer to anything.
+func (r *rewriter) shadowed(recv *receiver, pos token.Pos) bool {
+	_, obj := r.pkg.Types.Scope().Innermost(pos).LookupParent(recv.name, pos)
+	return obj != recv.obj
+}
+
+// receiverType returns the named struct type of the given method's receiver.
+func (r *rewriter) receiverType(decl *ast.FuncDecl) *types.TypeName {
+	obj, ok := r.pkg.Info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	recv := obj.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+
+	typ := recv.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != r.pkg.Types {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	return named.Obj()
+}
+
+// usesTime returns true if the given node contains a rewritable call.
+func (r *rewriter) usesTime(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if _, _, ok := r.calledFunction(call); ok {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// calledFunction returns the package path and name of the time or context
+// function invoked by the given call, if it has a glock equivalent.
+func (r *rewriter) calledFunction(call *ast.CallExpr) (string, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+
+	fn, ok := r.pkg.Info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return "", "", false
+	}
+
+	switch fn.Pkg().Path() {
+	case "time":
+		_, ok = clockMethods[fn.Name()]
+	case "context":
+		_, ok = contextFunctions[fn.Name()]
+		ok = ok && len(call.Args) == 2
+	default:
+		ok = false
+	}
+
+	return fn.Pkg().Path(), fn.Name(), ok
+}
+
+// timeType returns the name of the glock interface that replaces the given type
+// expression, if it denotes *time.Ticker or *time.Timer.
+func (r *rewriter) timeType(expr ast.Expr) (string, bool) {
+	ptr, ok := r.pkg.Info.TypeOf(expr).(*types.Pointer)
+	if !ok {
+		return "", false
+	}
+
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "time" {
+		return "", false
+	}
+
+	name, ok := glockTypes[named.Obj().Name()]
+	return name, ok
+}
+
+// hasGlockClock returns true if the given struct type already had a glock.Clock
+// field before the rewrite.
+func (r *rewriter) hasGlockClock(obj *types.TypeName) bool {
+	st := obj.Type().Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		if isGlockType(st.Field(i).Type(), "Clock") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *rewriter) replace(file *ast.File, node ast.Node, text string) {
+	r.edits[file] = append(r.edits[file], edit{pos: node.Pos(), end: node.End(), text: text})
+}
+
+func (r *rewriter) insert(file *ast.File, pos token.Pos, text string) {
+	r.edits[file] = append(r.edits[file], edit{pos: pos, end: pos, text: text})
+}
+
+func (r *rewriter) line(pos token.Pos) int {
+	return r.pkg.Fset.Position(pos).Line
+}
+
+func (r *rewriter) warn(pos token.Pos, format string, args ...interface{}) {
+	r.warnings = append(r.warnings, Warning{
+		Pos:     r.pkg.Fset.Position(pos),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// apply applies the given edits to the named file, then fixes up its imports
+// and formats the result.
+func (r *rewriter) apply(name string, edits []edit) ([]byte, error) {
+	source, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply edits from the end of the file so that earlier offsets stay
+	// valid. Edits at the same offset are applied in reverse so that their
+	// text appears in the order the edits were made.
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].pos > edits[j].pos })
+
+	tokFile := r.pkg.Fset.File(edits[0].pos)
+	for _, e := range edits {
+		start, end := tokFile.Offset(e.pos), tokFile.Offset(e.end)
+		source = append(source[:start:start], append([]byte(e.text), source[end:]...)...)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, source, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	astutil.AddImport(fset, file, glockPath)
+	for _, path := range []string{glockPath, "time", "context"} {
+		if !astutil.UsesImport(file, path) {
+			astutil.DeleteImport(fset, file, path)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+
+	// Group the glock import with the other non-standard imports
+	return imports.Process(name, buf.Bytes(), &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true})
+}
+
+// isGlockType returns true if the given type is the named glock type.
+func isGlockType(typ types.Type, name string) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == glockPath && named.Obj().Name() == name
+}
+
+// createdType returns the name of the time package type created by the given
+// function.
+func createdType(name string) string {
+	if name == "NewTicker" {
+		return "Ticker"
+	}
+
+	return "Timer"
+}
+
+// fieldNames returns the comma-separated names declared by the given field.
+func fieldNames(field *ast.Field) string {
+	var names []string
+	for _, ident := range field.Names {
+		names = append(names, ident.Name)
+	}
+	if len(names) == 0 {
+		return types.ExprString(field.Type)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// receiverName returns a receiver name for the given type name.
+func receiverName(typeName string) string {
+	for _, r := range typeName {
+		return string(unicode.ToLower(r))
+	}
+
+	return "r"
+}
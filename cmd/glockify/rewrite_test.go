@@ -0,0 +1,175 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.input"))
+	require.Nil(t, err)
+
+	for _, input := range inputs {
+		input := input
+		name := strings.TrimSuffix(filepath.Base(input), ".input")
+
+		t.Run(name, func(t *testing.T) {
+			files, _, err := Rewrite(loadPackage(t, input))
+			require.Nil(t, err)
+			require.Len(t, files, 1)
+			assert.Equal(t, input, files[0].Name)
+
+			expected, err := os.ReadFile(strings.TrimSuffix(input, ".input") + ".golden")
+			require.Nil(t, err)
+			assert.Equal(t, string(expected), string(files[0].Source))
+
+			// The rewritten source must still compile against glock
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, files[0].Name, files[0].Source, parser.ParseComments)
+			require.Nil(t, err)
+			checkPackage(t, fset, file)
+		})
+	}
+}
+
+func TestRewriteWarnings(t *testing.T) {
+	pkg := loadPackage(t, filepath.Join("testdata", "server.input"))
+	_, warnings, err := Rewrite(pkg)
+	require.Nil(t, err)
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+
+	assert.Equal(t, []string{
+		"testdata/server.input:9:6: struct Server is exported; values created outside this package must set its clock field",
+		"testdata/server.input:18:11: call to time.NewTicker is not in a method of a struct; replaced with a real clock, pass a glock.Clock by hand",
+		"testdata/server.input:55:9: call to time.Since is not in a method of a struct; pass a glock.Clock by hand",
+		"testdata/server.input:61:9: new(config) leaves its clock field nil; initialize it by hand",
+		"testdata/server.input:65:6: zero value of Server leaves its clock field nil; initialize it by hand",
+	}, messages)
+}
+
+func TestRewriteEscapingWarnings(t *testing.T) {
+	pkg := loadPackage(t, filepath.Join("testdata", "escape.input"))
+	_, warnings, err := Rewrite(pkg)
+	require.Nil(t, err)
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+
+	assert.Equal(t, []string{
+		"testdata/escape.input:7:6: struct Poller is exported; values created outside this package must set its clock field",
+		"testdata/escape.input:13:10: result of time.NewTimer is used as a *time.Timer; migrate it to glock.Timer by hand",
+		"testdata/escape.input:20:9: result of time.NewTimer is used as a *time.Timer; migrate it to glock.Timer by hand",
+		"testdata/escape.input:24:12: result of time.NewTicker is used as a *time.Ticker; migrate it to glock.Ticker by hand",
+		"testdata/escape.input:31:12: result of time.NewTicker is used as a *time.Ticker; migrate it to glock.Ticker by hand",
+		"testdata/escape.input:40:3: call to time.Sleep is in a scope where the receiver p is shadowed; pass a glock.Clock by hand",
+		"testdata/escape.input:48:2: call to time.Sleep is in a scope where the receiver p is shadowed; pass a glock.Clock by hand",
+	}, messages)
+}
+
+func TestRewriteFieldWarnings(t *testing.T) {
+	pkg := loadPackage(t, filepath.Join("testdata", "worker.input"))
+	_, warnings, err := Rewrite(pkg)
+	require.Nil(t, err)
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+
+	assert.Equal(t, []string{
+		"testdata/worker.input:8:2: field ticker is used as a *time.Ticker; migrate it to glock.Ticker by hand",
+		"testdata/worker.input:14:11: call to time.NewTicker is not in a method of a struct; pass a glock.Clock by hand",
+		"testdata/worker.input:15:11: call to time.NewTimer is not in a method of a struct; replaced with a real clock, pass a glock.Clock by hand",
+	}, messages)
+}
+
+func TestRewriteUnchanged(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "plain.go", "package plain\n\nfunc f() {}\n", parser.ParseComments)
+	require.Nil(t, err)
+
+	files, warnings, err := Rewrite(checkPackage(t, fset, file))
+	require.Nil(t, err)
+	assert.Empty(t, files)
+	assert.Empty(t, warnings)
+}
+
+func loadPackage(t *testing.T, filename string) *Package {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	require.Nil(t, err)
+
+	return checkPackage(t, fset, file)
+}
+
+func checkPackage(t *testing.T, fset *token.FileSet, files ...*ast.File) *Package {
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+
+	config := &types.Config{Importer: &glockImporter{Importer: importer.ForCompiler(fset, "source", nil)}}
+	pkg, err := config.Check(files[0].Name.Name, fset, files, info)
+	require.Nil(t, err)
+
+	return &Package{Fset: fset, Files: files, Types: pkg, Info: info}
+}
+
+// glockImporter imports glock from the source of the enclosing module and all
+// other packages with the wrapped importer.
+type glockImporter struct {
+	types.Importer
+	glock *types.Package
+}
+
+func (i *glockImporter) Import(path string) (*types.Package, error) {
+	if path != glockPath {
+		return i.Importer.Import(path)
+	}
+	if i.glock != nil {
+		return i.glock, nil
+	}
+
+	dir := filepath.Join("..", "..")
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	config := &types.Config{Importer: i.Importer}
+	if i.glock, err = config.Check(glockPath, fset, files, nil); err != nil {
+		return nil, err
+	}
+
+	return i.glock, nil
+}
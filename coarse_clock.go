@@ -0,0 +1,104 @@
+package glock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CoarseClock is an implementation of Clock whose Now method returns a cached
+// time that is refreshed by a background ticker, trading precision for a
+// cheaper read on hot paths. Since and Until are computed from the cached
+// time. All other methods are delegated to the underlying clock.
+type CoarseClock struct {
+	base   Clock
+	now    atomic.Value // time.Time
+	ticker Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+var _ Clock = &CoarseClock{}
+
+// NewCoarseClock creates a new CoarseClock that reads the real time once per
+// resolution. The clock must be closed to stop its background ticker.
+func NewCoarseClock(resolution time.Duration) *CoarseClock {
+	return NewCoarseClockFrom(NewRealClock(), resolution)
+}
+
+// NewCoarseClockFrom creates a new CoarseClock that reads the time of the given
+// clock once per resolution, as measured by a ticker of that clock. Wrapping a
+// MockClock, the cached time is refreshed when the mock clock is advanced past
+// a tick.
+func NewCoarseClockFrom(base Clock, resolution time.Duration) *CoarseClock {
+	c := &CoarseClock{
+		base:   base,
+		ticker: base.NewTicker(resolution),
+		done:   make(chan struct{}),
+	}
+	c.now.Store(base.Now())
+
+	c.wg.Add(1)
+	go c.refresh()
+	return c
+}
+
+// Close stops the background ticker. The cached time is no longer refreshed
+// once Close returns.
+func (c *CoarseClock) Close() {
+	c.once.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+		c.wg.Wait()
+	})
+}
+
+// Now returns the cached time, which lags the underlying clock by at most
+// the clock's resolution (plus scheduling delays).
+func (c *CoarseClock) Now() time.Time {
+	return c.now.Load().(time.Time)
+}
+
+func (c *CoarseClock) After(duration time.Duration) <-chan time.Time {
+	return c.base.After(duration)
+}
+
+func (c *CoarseClock) Sleep(duration time.Duration) {
+	c.base.Sleep(duration)
+}
+
+// Since returns the time elapsed since t, as read by Now.
+func (c *CoarseClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, as read by Now.
+func (c *CoarseClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *CoarseClock) NewTicker(duration time.Duration) Ticker {
+	return c.base.NewTicker(duration)
+}
+
+func (c *CoarseClock) NewTimer(duration time.Duration) Timer {
+	return c.base.NewTimer(duration)
+}
+
+func (c *CoarseClock) AfterFunc(duration time.Duration, f func()) Timer {
+	return c.base.AfterFunc(duration, f)
+}
+
+func (c *CoarseClock) refresh() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.ticker.Chan():
+			c.now.Store(c.base.Now())
+		case <-c.done:
+			return
+		}
+	}
+}
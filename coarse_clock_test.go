@@ -0,0 +1,88 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoarseClock(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0))
+	clock := NewCoarseClockFrom(base, time.Second)
+	defer clock.Close()
+
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+
+	// Now is not refreshed until the next tick
+	base.Advance(500 * time.Millisecond)
+	consistently(t, func() bool { return clock.Now().Equal(time.Unix(0, 0)) })
+	assert.Equal(t, 2*time.Second, clock.Until(time.Unix(2, 0)))
+
+	base.Advance(500 * time.Millisecond)
+	eventually(t, func() bool { return clock.Now().Equal(time.Unix(1, 0)) })
+	assert.Equal(t, time.Second, clock.Since(time.Unix(0, 0)))
+}
+
+func TestCoarseClockClose(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0))
+	clock := NewCoarseClockFrom(base, time.Second)
+	clock.Close()
+	clock.Close()
+
+	base.Advance(time.Second)
+	consistently(t, func() bool { return clock.Now().Equal(time.Unix(0, 0)) })
+}
+
+func TestCoarseClockReal(t *testing.T) {
+	t.Parallel()
+
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Close()
+
+	start := clock.Now()
+	eventually(t, func() bool { return clock.Now().After(start) })
+	assert.WithinDuration(t, time.Now(), clock.Now(), 100*time.Millisecond)
+}
+
+func BenchmarkRealClockNow(b *testing.B) {
+	clock := NewRealClock()
+
+	for i := 0; i < b.N; i++ {
+		clock.Now()
+	}
+}
+
+func BenchmarkCoarseClockNow(b *testing.B) {
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Close()
+
+	for i := 0; i < b.N; i++ {
+		clock.Now()
+	}
+}
+
+func BenchmarkRealClockNowParallel(b *testing.B) {
+	clock := NewRealClock()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			clock.Now()
+		}
+	})
+}
+
+func BenchmarkCoarseClockNowParallel(b *testing.B) {
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Close()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			clock.Now()
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package glock
+
+import (
+	"sync"
+	"time"
+)
+
+// Debouncer coalesces bursts of calls into a single invocation. A burst
+// ends once no call has been made for the configured wait duration. All
+// timing is performed with timers created by the debouncer's clock.
+type Debouncer struct {
+	clock      Clock
+	wait       time.Duration
+	leading    bool
+	trailing   bool
+	maxWait    time.Duration
+	mu         sync.Mutex
+	timer      Timer
+	pending    func()
+	burstStart time.Time
+
+	// generation is incremented each time the active timer is replaced or
+	// stopped. Each timer callback carries the generation it was scheduled
+	// with, so a callback of a timer that was stopped too late to prevent it
+	// from running sees a stale generation and does nothing.
+	generation int
+}
+
+// DebouncerOption configures a Debouncer.
+type DebouncerOption func(d *Debouncer)
+
+// DebounceLeading controls whether the first call of a burst is invoked
+// immediately. This is disabled by default.
+func DebounceLeading(enabled bool) DebouncerOption {
+	return func(d *Debouncer) { d.leading = enabled }
+}
+
+// DebounceTrailing controls whether the last call of a burst is invoked
+// once the burst ends. This is enabled by default.
+func DebounceTrailing(enabled bool) DebouncerOption {
+	return func(d *Debouncer) { d.trailing = enabled }
+}
+
+// DebounceMaxWait bounds the length of a burst. Once the given duration has
+// elapsed since the start of a burst, the burst ends even if calls are still
+// being made. A zero value (the default) does not bound bursts.
+func DebounceMaxWait(maxWait time.Duration) DebouncerOption {
+	return func(d *Debouncer) { d.maxWait = maxWait }
+}
+
+// NewDebouncer creates a new Debouncer that waits for the given duration of
+// inactivity before ending a burst of calls.
+func NewDebouncer(clock Clock, wait time.Duration, opts ...DebouncerOption) *Debouncer {
+	d := &Debouncer{
+		clock:    clock,
+		wait:     wait,
+		trailing: true,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Call registers a call to f. Depending on the debouncer's configuration, f
+// is invoked immediately (on the leading edge of a burst), once the burst
+// ends (on the trailing edge of a burst), or not at all if a later call
+// supersedes it. Leading-edge calls are invoked in the caller's goroutine;
+// trailing-edge calls are invoked in the goroutine of the underlying timer.
+func (d *Debouncer) Call(f func()) {
+	d.mu.Lock()
+
+	now := d.clock.Now()
+	var invoke func()
+
+	if d.timer == nil {
+		d.burstStart = now
+
+		if d.leading {
+			invoke = f
+		} else {
+			d.pending = f
+		}
+	} else {
+		d.pending = f
+	}
+
+	delay := d.wait
+	if d.maxWait > 0 {
+		if remaining := d.burstStart.Add(d.maxWait).Sub(now); remaining < delay {
+			delay = remaining
+		}
+	}
+
+	if delay > 0 {
+		d.schedule(delay)
+	} else {
+		// The burst has exceeded its maximum length but the timer has
+		// not yet been serviced; end the burst in this goroutine instead.
+		// A leading-edge call of a burst that ends immediately is kept.
+		if f := d.end(); f != nil {
+			invoke = f
+		}
+	}
+
+	d.mu.Unlock()
+
+	if invoke != nil {
+		invoke()
+	}
+}
+
+// Flush ends the current burst immediately, invoking the pending call (if
+// any) in the caller's goroutine.
+func (d *Debouncer) Flush() {
+	d.mu.Lock()
+	f := d.pending
+	d.cancel()
+	d.mu.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
+
+// Cancel ends the current burst without invoking the pending call.
+func (d *Debouncer) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.cancel()
+}
+
+// schedule replaces the active timer with one that ends the current burst
+// after the given delay. This method assumes the lock is held.
+func (d *Debouncer) schedule(delay time.Duration) {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	d.generation++
+	generation := d.generation
+	d.timer = d.clock.AfterFunc(delay, func() { d.fire(generation) })
+}
+
+// fire ends the burst associated with the given timer generation.
+func (d *Debouncer) fire(generation int) {
+	d.mu.Lock()
+	if generation != d.generation {
+		d.mu.Unlock()
+		return
+	}
+
+	f := d.end()
+	d.mu.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
+
+// end resets the debouncer to an idle state and returns the function that
+// should be invoked on the trailing edge, if any. This method assumes the
+// lock is held.
+func (d *Debouncer) end() func() {
+	f := d.pending
+	if !d.trailing {
+		f = nil
+	}
+
+	d.cancel()
+	return f
+}
+
+// cancel stops the active timer and clears the pending call. This method
+// assumes the lock is held.
+func (d *Debouncer) cancel() {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	d.generation++
+	d.timer = nil
+	d.pending = nil
+}
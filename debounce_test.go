@@ -0,0 +1,165 @@
+package glock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebouncer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("trailing", func(t *testing.T) {
+		t.Run("invokes last call after wait", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			calls := newCallRecorder()
+			debouncer := NewDebouncer(clock, time.Second)
+
+			debouncer.Call(calls.record(1))
+			clock.Advance(500 * time.Millisecond)
+			debouncer.Call(calls.record(2))
+			clock.Advance(500 * time.Millisecond)
+			debouncer.Call(calls.record(3))
+			clock.Advance(999 * time.Millisecond)
+			consistently(t, calls.equals())
+
+			clock.Advance(time.Millisecond)
+			eventually(t, calls.equals(3))
+			clock.Advance(time.Hour)
+			consistently(t, calls.equals(3))
+		})
+		t.Run("separate bursts", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			calls := newCallRecorder()
+			debouncer := NewDebouncer(clock, time.Second)
+
+			debouncer.Call(calls.record(1))
+			clock.Advance(time.Second)
+			eventually(t, calls.equals(1))
+
+			debouncer.Call(calls.record(2))
+			clock.Advance(time.Second)
+			eventually(t, calls.equals(1, 2))
+		})
+	})
+	t.Run("leading", func(t *testing.T) {
+		t.Run("invokes first call immediately", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			calls := newCallRecorder()
+			debouncer := NewDebouncer(clock, time.Second, DebounceLeading(true), DebounceTrailing(false))
+
+			debouncer.Call(calls.record(1))
+			assert.True(t, calls.equals(1)())
+
+			debouncer.Call(calls.record(2))
+			clock.Advance(time.Second)
+			consistently(t, calls.equals(1))
+
+			debouncer.Call(calls.record(3))
+			assert.True(t, calls.equals(1, 3)())
+		})
+		t.Run("with trailing", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			calls := newCallRecorder()
+			debouncer := NewDebouncer(clock, time.Second, DebounceLeading(true))
+
+			debouncer.Call(calls.record(1))
+			clock.Advance(time.Second)
+			consistently(t, calls.equals(1))
+
+			debouncer.Call(calls.record(2))
+			debouncer.Call(calls.record(3))
+			assert.True(t, calls.equals(1, 2)())
+
+			clock.Advance(time.Second)
+			eventually(t, calls.equals(1, 2, 3))
+		})
+		t.Run("zero wait", func(t *testing.T) {
+			for _, trailing := range []bool{true, false} {
+				clock := NewMockClockAt(time.Unix(0, 0))
+				calls := newCallRecorder()
+				debouncer := NewDebouncer(clock, 0, DebounceLeading(true), DebounceTrailing(trailing))
+
+				debouncer.Call(calls.record(1))
+				debouncer.Call(calls.record(2))
+				assert.True(t, calls.equals(1, 2)())
+			}
+		})
+	})
+	t.Run("max wait", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		debouncer := NewDebouncer(clock, time.Second, DebounceMaxWait(2*time.Second))
+
+		debouncer.Call(calls.record(1))
+		clock.Advance(900 * time.Millisecond)
+		debouncer.Call(calls.record(2))
+		clock.Advance(900 * time.Millisecond)
+		debouncer.Call(calls.record(3))
+		clock.Advance(100 * time.Millisecond)
+		consistently(t, calls.equals())
+
+		clock.Advance(100 * time.Millisecond)
+		eventually(t, calls.equals(3))
+	})
+	t.Run("flush", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		debouncer := NewDebouncer(clock, time.Second)
+
+		debouncer.Call(calls.record(1))
+		debouncer.Flush()
+		assert.True(t, calls.equals(1)())
+
+		clock.Advance(time.Second)
+		consistently(t, calls.equals(1))
+	})
+	t.Run("cancel", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		debouncer := NewDebouncer(clock, time.Second)
+
+		debouncer.Call(calls.record(1))
+		debouncer.Cancel()
+
+		clock.Advance(time.Second)
+		consistently(t, calls.equals())
+	})
+}
+
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []int
+}
+
+func newCallRecorder() *callRecorder {
+	return &callRecorder{}
+}
+
+func (r *callRecorder) record(value int) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.calls = append(r.calls, value)
+	}
+}
+
+func (r *callRecorder) equals(expected ...int) func() bool {
+	return func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if len(r.calls) != len(expected) {
+			return false
+		}
+		for i, value := range expected {
+			if r.calls[i] != value {
+				return false
+			}
+		}
+
+		return true
+	}
+}
@@ -0,0 +1,98 @@
+package glock
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	defaultMu    sync.RWMutex
+	defaultClock Clock = NewRealClock()
+)
+
+// Default returns the process-wide clock used by the package-level time
+// functions. It is a real clock unless replaced by SetDefault.
+func Default() Clock {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultClock
+}
+
+// SetDefault replaces the process-wide clock. The returned function restores
+// the clock that was replaced.
+func SetDefault(clock Clock) (restore func()) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	previous := defaultClock
+	defaultClock = clock
+
+	return func() {
+		defaultMu.Lock()
+		defer defaultMu.Unlock()
+
+		defaultClock = previous
+	}
+}
+
+// Now returns the current time of the default clock.
+func Now() time.Time {
+	return Default().Now()
+}
+
+// After returns a channel which receives the current time of the default
+// clock after the given duration elapses.
+func After(duration time.Duration) <-chan time.Time {
+	return Default().After(duration)
+}
+
+// Sleep blocks until the given duration elapses on the default clock.
+func Sleep(duration time.Duration) {
+	Default().Sleep(duration)
+}
+
+// Since returns the time elapsed since t on the default clock.
+func Since(t time.Time) time.Duration {
+	return Default().Since(t)
+}
+
+// Until returns the duration until t on the default clock.
+func Until(t time.Time) time.Duration {
+	return Default().Until(t)
+}
+
+// NewTicker creates a new Ticker on the default clock.
+func NewTicker(duration time.Duration) Ticker {
+	return Default().NewTicker(duration)
+}
+
+// NewTimer creates a new Timer on the default clock.
+func NewTimer(duration time.Duration) Timer {
+	return Default().NewTimer(duration)
+}
+
+// NewTickerImmediate creates a ticker on the default clock that ticks once
+// immediately and then at the given interval.
+func NewTickerImmediate(duration time.Duration) Ticker {
+	return NewTickerImmediateOn(Default(), duration)
+}
+
+// NewJitteredTicker creates a ticker on the default clock whose intervals are
+// drawn as described by TickerClock.NewJitteredTicker.
+func NewJitteredTicker(duration time.Duration, jitter float64, r *rand.Rand) Ticker {
+	return NewJitteredTickerOn(Default(), duration, jitter, r)
+}
+
+// NewPolicyTicker creates a ticker on the default clock that handles missed
+// ticks according to the given policy.
+func NewPolicyTicker(duration time.Duration, policy TickPolicy) PolicyTicker {
+	return NewPolicyTickerOn(Default(), duration, policy)
+}
+
+// AfterFunc calls the given function in its own goroutine after the given
+// duration elapses on the default clock.
+func AfterFunc(duration time.Duration, f func()) Timer {
+	return Default().AfterFunc(duration, f)
+}
@@ -0,0 +1,41 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	_, ok := Default().(*realClock)
+	assert.True(t, ok, "expected default clock to be real")
+
+	clock := NewMockClockAt(time.Unix(100, 0))
+	restore := SetDefault(clock)
+	assert.Same(t, clock, Default())
+	assert.Equal(t, time.Unix(100, 0), Now())
+	assert.Equal(t, 10*time.Second, Since(time.Unix(90, 0)))
+	assert.Equal(t, 10*time.Second, Until(time.Unix(110, 0)))
+
+	after := After(time.Second)
+	called := make(chan struct{})
+	AfterFunc(time.Second, func() { close(called) })
+	immediate := NewTickerImmediate(time.Second)
+	defer immediate.Stop()
+	jittered := NewJitteredTicker(time.Second, 0.5, nil)
+	defer jittered.Stop()
+	policy := NewPolicyTicker(time.Second, DropMissedTicks)
+	defer policy.Stop()
+	assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, clock.GetTickerArgs())
+
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(after, time.Unix(101, 0)))
+	eventually(t, chanClosed(called))
+	eventually(t, chanReceives(immediate.Chan(), time.Unix(100, 0)))
+	eventually(t, tickReceives(policy.Ticks(), Tick{Time: time.Unix(101, 0)}))
+
+	restore()
+	_, ok = Default().(*realClock)
+	assert.True(t, ok, "expected real clock to be restored")
+}
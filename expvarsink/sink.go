@@ -0,0 +1,100 @@
+// Package expvarsink provides a glock.MetricsSink that publishes the metrics
+// of an InstrumentedClock as expvar variables.
+package expvarsink
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// Sink is a glock.MetricsSink that publishes the following variables in an
+// expvar map. Durations are in nanoseconds.
+//
+//   - live_timers, live_tickers: the number of timers and tickers currently live
+//   - timers_started, tickers_started: the total number of timers and tickers started
+//   - sleeps, sleep_requested_ns, sleep_actual_ns: the number and total durations
+//     of sleeps
+//   - sleep_overshoot_max_ns: the largest amount by which a sleep overran
+//   - ticks, tick_lag_ns: the number of ticks received and their total lag
+//   - tick_lag_max_ns: the largest lag between a tick and its receipt
+type Sink struct {
+	vars *expvar.Map
+	mu   sync.Mutex
+
+	sleepOvershootMax expvar.Int
+	tickLagMax        expvar.Int
+}
+
+var _ glock.MetricsSink = &Sink{}
+
+// New creates a Sink that publishes its variables in an expvar map with the
+// given name. Like expvar.NewMap, it panics if the name is already in use.
+func New(name string) *Sink {
+	s := &Sink{vars: expvar.NewMap(name)}
+	s.vars.Set("sleep_overshoot_max_ns", &s.sleepOvershootMax)
+	s.vars.Set("tick_lag_max_ns", &s.tickLagMax)
+
+	for _, key := range []string{
+		"live_timers",
+		"live_tickers",
+		"timers_started",
+		"tickers_started",
+		"sleeps",
+		"sleep_requested_ns",
+		"sleep_actual_ns",
+		"ticks",
+		"tick_lag_ns",
+	} {
+		s.vars.Add(key, 0)
+	}
+
+	return s
+}
+
+// Map returns the expvar map holding the sink's variables.
+func (s *Sink) Map() *expvar.Map {
+	return s.vars
+}
+
+func (s *Sink) TimerStarted() {
+	s.vars.Add("live_timers", 1)
+	s.vars.Add("timers_started", 1)
+}
+
+func (s *Sink) TimerStopped() {
+	s.vars.Add("live_timers", -1)
+}
+
+func (s *Sink) TickerStarted() {
+	s.vars.Add("live_tickers", 1)
+	s.vars.Add("tickers_started", 1)
+}
+
+func (s *Sink) TickerStopped() {
+	s.vars.Add("live_tickers", -1)
+}
+
+func (s *Sink) SleepObserved(requested, actual time.Duration) {
+	s.vars.Add("sleeps", 1)
+	s.vars.Add("sleep_requested_ns", int64(requested))
+	s.vars.Add("sleep_actual_ns", int64(actual))
+	s.setMax(&s.sleepOvershootMax, actual-requested)
+}
+
+func (s *Sink) TickObserved(lag time.Duration) {
+	s.vars.Add("ticks", 1)
+	s.vars.Add("tick_lag_ns", int64(lag))
+	s.setMax(&s.tickLagMax, lag)
+}
+
+func (s *Sink) setMax(v *expvar.Int, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if int64(duration) > v.Value() {
+		v.Set(int64(duration))
+	}
+}
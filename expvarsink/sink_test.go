@@ -0,0 +1,72 @@
+package expvarsink
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	sink := New(uniqueName())
+
+	base := glock.NewMockClockAt(time.Unix(0, 0))
+	clock := glock.NewInstrumentedClock(base, sink)
+
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Second)
+		close(done)
+	}()
+	base.BlockingAdvance(2 * time.Second)
+	<-done
+
+	timer := clock.NewTimer(time.Second)
+	clock.NewTimer(time.Second)
+	timer.Stop()
+
+	ticker := clock.NewTicker(time.Second)
+	base.Advance(time.Second)
+	base.Advance(500 * time.Millisecond)
+	<-ticker.Chan()
+	ticker.Stop()
+
+	expected := map[string]int64{
+		"live_timers":            0,
+		"live_tickers":           0,
+		"timers_started":         2,
+		"tickers_started":        1,
+		"sleeps":                 1,
+		"sleep_requested_ns":     int64(time.Second),
+		"sleep_actual_ns":        int64(2 * time.Second),
+		"sleep_overshoot_max_ns": int64(time.Second),
+		"ticks":                  1,
+		"tick_lag_ns":            int64(500 * time.Millisecond),
+		"tick_lag_max_ns":        int64(500 * time.Millisecond),
+	}
+
+	assert.Eventually(t, func() bool {
+		var actual map[string]int64
+		require.NoError(t, json.Unmarshal([]byte(sink.Map().String()), &actual))
+		return assert.ObjectsAreEqual(expected, actual)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewDuplicateName(t *testing.T) {
+	name := uniqueName()
+	New(name)
+	assert.Panics(t, func() { New(name) })
+}
+
+var nameCounter int64
+
+// uniqueName returns an unused expvar name, as tests may run several times in
+// one process.
+func uniqueName() string {
+	return fmt.Sprintf("glock_test_%d", atomic.AddInt64(&nameCounter, 1))
+}
@@ -0,0 +1,14 @@
+// Command glockcheck reports direct uses of the time package in code that has a
+// glock.Clock available. It can be run standalone or as a vet tool:
+//
+//	go vet -vettool=$(which glockcheck) ./...
+package main
+
+import (
+	"github.com/derision-test/glock/glockcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(glockcheck.Analyzer)
+}
@@ -0,0 +1,457 @@
+// Package glockcheck defines an analyzer that reports direct uses of the time
+// package (and of the context package's deadline functions) in code that has
+// a glock.Clock available.
+//
+// The analyzer only inspects packages that import glock, or that receive a
+// glock.Clock through the types of another package (for example, a parameter
+// whose struct type has a glock.Clock field). Each report comes with a suggested
+// fix that rewrites the call to use a Clock that is in scope at the call site:
+// a variable or parameter of type glock.Clock, or a glock.Clock field of a
+// variable (such as a method receiver). Fixes that create a timer or ticker
+// also rewrite reads of its C field to calls of its Chan method, and are only
+// offered when every use of the result can be found and made through the glock
+// Timer or Ticker interface.
+package glockcheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const glockPath = "github.com/derision-test/glock"
+
+// Analyzer reports calls to time and context functions that should be made
+// through a glock.Clock.
+var Analyzer = &analysis.Analyzer{
+	Name:     "glockcheck",
+	Doc:      "report direct uses of the time package in code that has a glock.Clock available",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// clockMethods maps the time package functions that have an equivalent Clock
+// method to the name of that method.
+var clockMethods = map[string]string{
+	"Now":       "Now",
+	"Sleep":     "Sleep",
+	"After":     "After",
+	"NewTimer":  "NewTimer",
+	"NewTicker": "NewTicker",
+	"AfterFunc": "AfterFunc",
+	"Since":     "Since",
+	"Until":     "Until",
+}
+
+// contextFunctions maps the context package functions that have an equivalent
+// glock function to the name of that function.
+var contextFunctions = map[string]string{
+	"WithTimeout":  "ContextWithTimeout",
+	"WithDeadline": "ContextWithDeadline",
+}
+
+// channelFunctions maps the time package functions that return a timer or
+// ticker to the fields and methods of the result that can be used through the
+// glock equivalent. The C field is replaced by the Chan method.
+var channelFunctions = map[string]map[string]bool{
+	"NewTimer":  {"C": true, "Stop": true, "Reset": true},
+	"NewTicker": {"C": true, "Stop": true},
+	"AfterFunc": {"C": true, "Stop": true, "Reset": true},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	clockType := lookupClockType(pass)
+	if clockType == nil {
+		// Package neither imports glock nor receives a glock.Clock
+		return nil, nil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.File)(nil), (*ast.CallExpr)(nil)}
+
+	var file *ast.File
+	inspect.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		if f, ok := n.(*ast.File); ok {
+			file = f
+			return true
+		}
+
+		call := n.(*ast.CallExpr)
+		fn, ok := calledFunction(pass.TypesInfo, call)
+		if !ok {
+			return true
+		}
+
+		switch fn.Pkg().Path() {
+		case "time":
+			if method, ok := clockMethods[fn.Name()]; ok {
+				checkTimeCall(pass, clockType, file, call, stack[len(stack)-2], fn, method)
+			}
+
+		case "context":
+			if function, ok := contextFunctions[fn.Name()]; ok {
+				checkContextCall(pass, clockType, file, call, fn, function)
+			}
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// lookupClockType returns the glock.Clock type if the package being analyzed
+// is not glock and either imports it or receives a glock.Clock through another
+// package's types; nil is returned otherwise.
+func lookupClockType(pass *analysis.Pass) types.Type {
+	if pass.Pkg.Path() == glockPath {
+		// Leave the real clock implementation alone
+		return nil
+	}
+
+	for _, imported := range pass.Pkg.Imports() {
+		if imported.Path() == glockPath {
+			if obj, ok := imported.Scope().Lookup("Clock").(*types.TypeName); ok {
+				return obj.Type()
+			}
+		}
+	}
+
+	return receivedClockType(pass)
+}
+
+// receivedClockType returns the glock.Clock type if a variable declared in the
+// package being analyzed has that type, or is a struct (or pointer to struct)
+// with an accessible field of that type. This finds a Clock passed in through
+// the types of a package that imports glock, such as an options struct.
+func receivedClockType(pass *analysis.Pass) types.Type {
+	for _, obj := range pass.TypesInfo.Defs {
+		v, ok := obj.(*types.Var)
+		if !ok {
+			continue
+		}
+
+		typ := v.Type()
+		if isClockType(typ) {
+			return typ
+		}
+
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			if (field.Exported() || field.Pkg() == pass.Pkg) && isClockType(field.Type()) {
+				return field.Type()
+			}
+		}
+	}
+
+	return nil
+}
+
+// isClockType returns true if the given type is glock.Clock.
+func isClockType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == glockPath && obj.Name() == "Clock"
+}
+
+// calledFunction returns the package-level function invoked by the given call.
+func calledFunction(info *types.Info, call *ast.CallExpr) (*types.Func, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return nil, false
+	}
+
+	return fn, true
+}
+
+func checkTimeCall(pass *analysis.Pass, clockType types.Type, file *ast.File, call *ast.CallExpr, parent ast.Node, fn *types.Func, method string) {
+	diagnostic := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("call to time.%s should use a glock.Clock", fn.Name()),
+	}
+
+	var edits []analysis.TextEdit
+	fixable := true
+	if members, ok := channelFunctions[fn.Name()]; ok {
+		edits, fixable = channelEdits(pass.TypesInfo, file, call, parent, members)
+	}
+
+	if clock, ok := findClock(pass, clockType, call.Pos()); ok && fixable {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Replace with %s.%s", clock, method),
+			TextEdits: append([]analysis.TextEdit{{
+				Pos:     call.Fun.Pos(),
+				End:     call.Fun.End(),
+				NewText: []byte(clock + "." + method),
+			}}, edits...),
+		}}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// channelEdits returns the edits that replace reads of the C field of the timer
+// or ticker returned by the given call with calls of the Chan method. The result
+// may be used directly or through a variable defined by the call's enclosing
+// statement, but only by selecting one of the given members (those that the
+// glock equivalent provides). If it is used in any other way (e.g. assigned to
+// an existing variable or field, returned, or passed to a function), the fix
+// would not compile and false is returned.
+func channelEdits(info *types.Info, file *ast.File, call *ast.CallExpr, parent ast.Node, members map[string]bool) ([]analysis.TextEdit, bool) {
+	var name *ast.Ident
+
+	switch parent := parent.(type) {
+	case *ast.ExprStmt:
+		return nil, true
+
+	case *ast.SelectorExpr:
+		if !members[parent.Sel.Name] {
+			return nil, false
+		}
+		if parent.Sel.Name == "C" {
+			return []analysis.TextEdit{chanEdit(parent)}, true
+		}
+
+		return nil, true
+
+	case *ast.AssignStmt:
+		if parent.Tok != token.DEFINE || len(parent.Lhs) != len(parent.Rhs) {
+			return nil, false
+		}
+
+		for i, rhs := range parent.Rhs {
+			if rhs == call {
+				name, _ = parent.Lhs[i].(*ast.Ident)
+			}
+		}
+
+	case *ast.ValueSpec:
+		if parent.Type != nil || len(parent.Names) != len(parent.Values) {
+			return nil, false
+		}
+
+		for i, value := range parent.Values {
+			if value == call {
+				name = parent.Names[i]
+			}
+		}
+	}
+
+	if name == nil {
+		return nil, false
+	}
+	if name.Name == "_" {
+		return nil, true
+	}
+
+	obj := info.Defs[name]
+	if obj == nil {
+		// Existing variable reused by a short variable declaration
+		return nil, false
+	}
+
+	uses := 0
+	for _, used := range info.Uses {
+		if used == obj {
+			uses++
+		}
+	}
+
+	var edits []analysis.TextEdit
+	selected := 0
+	fixable := true
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if ident, ok := sel.X.(*ast.Ident); ok && info.Uses[ident] == obj {
+			selected++
+
+			if !members[sel.Sel.Name] {
+				fixable = false
+			} else if sel.Sel.Name == "C" {
+				edits = append(edits, chanEdit(sel))
+			}
+		}
+
+		return true
+	})
+
+	if !fixable || selected != uses {
+		// Some use is not expressible through the glock interface
+		return nil, false
+	}
+
+	return edits, true
+}
+
+// chanEdit returns an edit that replaces the given read of a C field with a
+// call of the Chan method.
+func chanEdit(sel *ast.SelectorExpr) analysis.TextEdit {
+	return analysis.TextEdit{
+		Pos:     sel.Sel.Pos(),
+		End:     sel.Sel.End(),
+		NewText: []byte("Chan()"),
+	}
+}
+
+func checkContextCall(pass *analysis.Pass, clockType types.Type, file *ast.File, call *ast.CallExpr, fn *types.Func, function string) {
+	diagnostic := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("call to context.%s should use glock.%s", fn.Name(), function),
+	}
+
+	clock, ok := findClock(pass, clockType, call.Pos())
+	glockName, imported := importName(file, glockPath)
+
+	if ok && imported && len(call.Args) == 2 {
+		// context.WithTimeout(ctx, d) -> glock.ContextWithTimeout(ctx, clock, d)
+		newText := fmt.Sprintf("%s.%s(%s, %s, %s)",
+			glockName,
+			function,
+			render(pass.Fset, call.Args[0]),
+			clock,
+			render(pass.Fset, call.Args[1]),
+		)
+
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Replace with %s.%s", glockName, function),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(newText),
+			}},
+		}}
+	}
+
+	pass.Report(diagnostic)
+}
+
+// findClock returns an expression that evaluates to a glock.Clock in scope at
+// the given position. Variables of type glock.Clock are preferred (innermost
+// scope first) over glock.Clock fields of in-scope variables. Variables whose
+// name is shadowed at the given position are ignored.
+func findClock(pass *analysis.Pass, clockType types.Type, pos token.Pos) (string, bool) {
+	var fields []string
+
+	for scope := pass.Pkg.Scope().Innermost(pos); scope != nil && scope != types.Universe; scope = scope.Parent() {
+		var candidates []string
+
+		for _, name := range scope.Names() {
+			v, ok := scope.Lookup(name).(*types.Var)
+			if !ok || name == "_" || (scope != pass.Pkg.Scope() && v.Pos() > pos) {
+				continue
+			}
+			if _, obj := pass.Pkg.Scope().Innermost(pos).LookupParent(name, pos); obj != v {
+				// Shadowed by an inner declaration
+				continue
+			}
+
+			if types.Identical(v.Type(), clockType) {
+				candidates = append(candidates, name)
+			} else if field, ok := clockField(pass.Pkg, v.Type(), clockType); ok {
+				fields = append(fields, name+"."+field)
+			}
+		}
+
+		if len(candidates) > 0 {
+			sort.Strings(candidates)
+			return candidates[0], true
+		}
+	}
+
+	if len(fields) > 0 {
+		return fields[0], true
+	}
+
+	return "", false
+}
+
+// clockField returns the name of a field of type glock.Clock in the given
+// struct (or pointer to struct) type that is accessible from the given package.
+func clockField(pkg *types.Package, typ types.Type, clockType types.Type) (string, bool) {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return "", false
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() && field.Pkg() != pkg {
+			continue
+		}
+
+		if types.Identical(field.Type(), clockType) {
+			return field.Name(), true
+		}
+	}
+
+	return "", false
+}
+
+// importName returns the name under which the given file imports the package
+// with the given path.
+func importName(file *ast.File, path string) (string, bool) {
+	for _, spec := range file.Imports {
+		if spec.Path.Value != fmt.Sprintf("%q", path) {
+			continue
+		}
+
+		if spec.Name == nil {
+			return "glock", true
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return "", false
+		}
+
+		return spec.Name.Name, true
+	}
+
+	return "", false
+}
+
+// render returns the source text of the given expression.
+func render(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
@@ -0,0 +1,59 @@
+package a
+
+import (
+	"context"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+type server struct {
+	clock glock.Clock
+}
+
+func (s *server) handle(ctx context.Context) time.Duration {
+	start := time.Now()     // want `call to time.Now should use a glock.Clock`
+	time.Sleep(time.Second) // want `call to time.Sleep should use a glock.Clock`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second) // want `call to context.WithTimeout should use glock.ContextWithTimeout`
+	defer cancel()
+
+	<-time.After(time.Second) // want `call to time.After should use a glock.Clock`
+	return time.Since(start)  // want `call to time.Since should use a glock.Clock`
+}
+
+func poll(ctx context.Context, clock glock.Clock, deadline time.Time) {
+	ctx, cancel := context.WithDeadline(ctx, deadline) // want `call to context.WithDeadline should use glock.ContextWithDeadline`
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second) // want `call to time.NewTicker should use a glock.Clock`
+	defer ticker.Stop()
+
+	timer := time.NewTimer(time.Until(deadline)) // want `call to time.NewTimer should use a glock.Clock` `call to time.Until should use a glock.Clock`
+	defer timer.Stop()
+
+	time.AfterFunc(time.Second, func() {}) // want `call to time.AfterFunc should use a glock.Clock`
+}
+
+func noClock() time.Time {
+	d := 2 * time.Second
+	_ = d.Seconds()
+
+	return time.Now() // want `call to time.Now should use a glock.Clock`
+}
+
+func wait(clock glock.Clock) {
+	ticker := time.NewTicker(time.Second) // want `call to time.NewTicker should use a glock.Clock`
+	defer ticker.Stop()
+
+	<-ticker.C
+	<-time.NewTimer(time.Second).C // want `call to time.NewTimer should use a glock.Clock`
+}
+
+func keep(clock glock.Clock) *time.Timer {
+	var timer *time.Timer
+	timer = time.NewTimer(time.Second) // want `call to time.NewTimer should use a glock.Clock`
+	<-timer.C
+
+	return time.NewTimer(time.Second) // want `call to time.NewTimer should use a glock.Clock`
+}
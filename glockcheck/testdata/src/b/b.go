@@ -0,0 +1,7 @@
+package b
+
+import "time"
+
+func now() time.Time {
+	return time.Now()
+}
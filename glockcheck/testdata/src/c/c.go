@@ -0,0 +1,12 @@
+package c
+
+import (
+	"time"
+
+	"deps"
+)
+
+func run(opts deps.Options) time.Duration {
+	start := time.Now()      // want `call to time.Now should use a glock.Clock`
+	return time.Since(start) // want `call to time.Since should use a glock.Clock`
+}
@@ -0,0 +1,12 @@
+package d
+
+import (
+	"time"
+
+	"deps"
+)
+
+func name() string {
+	_ = time.Now()
+	return deps.Name
+}
@@ -0,0 +1,9 @@
+package deps
+
+import "github.com/derision-test/glock"
+
+type Options struct {
+	Clock glock.Clock
+}
+
+const Name = "deps"
@@ -0,0 +1,43 @@
+package e
+
+import (
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+func reset(clock glock.Clock) {
+	ticker := time.NewTicker(time.Second) // want `call to time.NewTicker should use a glock.Clock`
+	defer ticker.Stop()
+
+	ticker.Reset(2 * time.Second)
+	<-ticker.C
+}
+
+func resetDirect(clock glock.Clock) {
+	time.NewTicker(time.Second).Reset(2 * time.Second) // want `call to time.NewTicker should use a glock.Clock`
+}
+
+func passTicker(clock glock.Clock) {
+	ticker := time.NewTicker(time.Second) // want `call to time.NewTicker should use a glock.Clock`
+	ticker.Reset(2 * time.Second)
+	useTicker(ticker)
+}
+
+func passTimer(clock glock.Clock) {
+	timer := time.NewTimer(time.Second) // want `call to time.NewTimer should use a glock.Clock`
+	defer timer.Stop()
+
+	<-timer.C
+	useTimer(timer)
+}
+
+func storeTimer(clock glock.Clock) {
+	timer := time.NewTimer(time.Second) // want `call to time.NewTimer should use a glock.Clock`
+	timers := []*time.Timer{timer}
+	_ = timers
+}
+
+func useTicker(ticker *time.Ticker) {}
+
+func useTimer(timer *time.Timer) {}
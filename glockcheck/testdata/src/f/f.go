@@ -0,0 +1,28 @@
+package f
+
+import (
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+type config struct {
+	name string
+}
+
+func shadow(c glock.Clock) time.Time {
+	{
+		c := config{name: "shadow"}
+		_ = c.name
+
+		return time.Now() // want `call to time.Now should use a glock.Clock`
+	}
+}
+
+func inner(c glock.Clock) time.Time {
+	if c := 1; c > 0 {
+		return time.Now() // want `call to time.Now should use a glock.Clock`
+	}
+
+	return time.Now() // want `call to time.Now should use a glock.Clock`
+}
@@ -0,0 +1,36 @@
+package glock
+
+import (
+	"context"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+	Sleep(duration time.Duration)
+	Since(t time.Time) time.Duration
+	Until(t time.Time) time.Duration
+	NewTicker(duration time.Duration) Ticker
+	NewTimer(duration time.Duration) Timer
+	AfterFunc(duration time.Duration, f func()) Timer
+}
+
+type Ticker interface {
+	Chan() <-chan time.Time
+	Stop()
+}
+
+type Timer interface {
+	Chan() <-chan time.Time
+	Reset(duration time.Duration) bool
+	Stop() bool
+}
+
+func ContextWithDeadline(ctx context.Context, clock Clock, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, deadline)
+}
+
+func ContextWithTimeout(ctx context.Context, clock Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, timeout)
+}
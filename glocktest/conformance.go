@@ -0,0 +1,362 @@
+package glocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// Harness is a clock under test along with the means to move it forward.
+type Harness struct {
+	// Clock is the implementation under test.
+	Clock glock.Clock
+
+	// Advance moves the clock forward by the given duration. For clocks
+	// backed by real time, this should sleep.
+	Advance func(duration time.Duration)
+
+	// Unit is the base duration used by the conformance tests. Clocks
+	// backed by real time should use a value large enough to absorb
+	// scheduling jitter (e.g. 100ms).
+	Unit time.Duration
+
+	// DefersNonPositiveAfterFunc indicates that the clock calls AfterFunc
+	// callbacks created with a non-positive duration on the next call to
+	// Advance rather than immediately (e.g. a MockClock created with
+	// WithSynchronousAfterFunc).
+	DefersNonPositiveAfterFunc bool
+}
+
+// ClockFactory creates a fresh Harness for a single conformance test.
+type ClockFactory func(t *testing.T) Harness
+
+// RealHarness returns a Harness for a real clock.
+func RealHarness(t *testing.T) Harness {
+	return Harness{
+		Clock:   glock.NewRealClock(),
+		Advance: time.Sleep,
+		Unit:    100 * time.Millisecond,
+	}
+}
+
+// MockHarness returns a Harness for a mock clock.
+func MockHarness(t *testing.T) Harness {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+
+	return Harness{
+		Clock:   clock,
+		Advance: clock.Advance,
+		Unit:    time.Second,
+	}
+}
+
+const (
+	// receiveTimeout is the real time to wait for a value that is expected
+	// to be delivered.
+	receiveTimeout = time.Second
+
+	// settleTimeout is the real time to wait to confirm that a value is
+	// not delivered.
+	settleTimeout = 20 * time.Millisecond
+)
+
+// RunClockConformance runs a suite of tests that check that the clock
+// created by the given factory (and the timers and tickers it creates)
+// behaves like the time package. The factory is invoked once per test, and
+// tests run in parallel.
+func RunClockConformance(t *testing.T, factory ClockFactory) {
+	for _, test := range conformanceTests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.run(t, factory(t))
+		})
+	}
+}
+
+var conformanceTests = []struct {
+	name string
+	run  func(t *testing.T, h Harness)
+}{
+	{"Now advances", testNow},
+	{"Since and Until", testSinceUntil},
+	{"After fires after duration", testAfter},
+	{"After fires immediately for non-positive durations", testAfterNonPositive},
+	{"Sleep returns after duration", testSleep},
+	{"Timer fires once", testTimerFiresOnce},
+	{"Timer fires immediately for non-positive durations", testTimerNonPositive},
+	{"Timer Stop prevents firing", testTimerStop},
+	{"Timer Stop returns false after firing", testTimerStopAfterFire},
+	{"Timer Reset extends active timer", testTimerResetActive},
+	{"Timer Reset restarts fired timer", testTimerResetFired},
+	{"Timer Reset restarts stopped timer", testTimerResetStopped},
+	{"AfterFunc calls function after duration", testAfterFunc},
+	{"AfterFunc calls function immediately for non-positive durations", testAfterFuncNonPositive},
+	{"AfterFunc Stop prevents call", testAfterFuncStop},
+	{"Ticker ticks repeatedly", testTicker},
+	{"Ticker drops ticks for slow readers", testTickerDrops},
+	{"Ticker Stop prevents ticks", testTickerStop},
+	{"Ticker panics for non-positive durations", testTickerNonPositive},
+}
+
+func testNow(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	h.Advance(h.Unit)
+
+	if elapsed := h.Clock.Now().Sub(start); elapsed < h.Unit {
+		t.Errorf("expected Now to advance by at least %s, advanced by %s", h.Unit, elapsed)
+	}
+}
+
+func testSinceUntil(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	h.Advance(h.Unit)
+
+	if since := h.Clock.Since(start); since < h.Unit {
+		t.Errorf("expected Since to be at least %s, got %s", h.Unit, since)
+	}
+	if until := h.Clock.Until(start); until > -h.Unit {
+		t.Errorf("expected Until to be at most %s, got %s", -h.Unit, until)
+	}
+	if until := h.Clock.Until(start.Add(10 * h.Unit)); until > 9*h.Unit {
+		t.Errorf("expected Until to be at most %s, got %s", 9*h.Unit, until)
+	}
+}
+
+func testAfter(t *testing.T, h Harness) {
+	start := h.Clock.Now()
+	ch := h.Clock.After(2 * h.Unit)
+
+	h.Advance(h.Unit)
+	expectNoValue(t, ch, "After channel before duration elapsed")
+
+	h.Advance(h.Unit)
+	if value, ok := expectValue(t, ch, "After channel after duration elapsed"); ok && value.Before(start.Add(2*h.Unit)) {
+		t.Errorf("expected After to deliver a time no earlier than %s, got %s", start.Add(2*h.Unit), value)
+	}
+}
+
+func testAfterNonPositive(t *testing.T, h Harness) {
+	ch := h.Clock.After(0)
+	expectValue(t, ch, "After(0)")
+	expectNoValue(t, ch, "After(0) after it delivered a value")
+	expectValue(t, h.Clock.After(-h.Unit), "After with negative duration")
+}
+
+func testSleep(t *testing.T, h Harness) {
+	done := make(chan struct{})
+	go func() {
+		h.Clock.Sleep(h.Unit)
+		close(done)
+	}()
+
+	// The sleeping goroutine may not have started by the time the clock
+	// is first advanced, so keep advancing until it returns.
+	for i := 0; i < 100; i++ {
+		h.Advance(h.Unit)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(settleTimeout):
+		}
+	}
+
+	t.Errorf("expected Sleep to return after duration elapsed")
+}
+
+func testTimerFiresOnce(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(h.Unit)
+	expectNoValue(t, timer.Chan(), "timer before duration elapsed")
+
+	h.Advance(h.Unit)
+	expectValue(t, timer.Chan(), "timer after duration elapsed")
+
+	h.Advance(h.Unit)
+	expectNoValue(t, timer.Chan(), "timer after firing")
+}
+
+func testTimerNonPositive(t *testing.T, h Harness) {
+	expectValue(t, h.Clock.NewTimer(0).Chan(), "NewTimer(0)")
+	expectValue(t, h.Clock.NewTimer(-h.Unit).Chan(), "timer with negative duration")
+
+	timer := h.Clock.NewTimer(h.Unit)
+	timer.Reset(-h.Unit)
+	expectValue(t, timer.Chan(), "timer reset with negative duration")
+}
+
+func testTimerStop(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(2 * h.Unit)
+	h.Advance(h.Unit)
+
+	if !timer.Stop() {
+		t.Errorf("expected Stop to return true for an active timer")
+	}
+	if timer.Stop() {
+		t.Errorf("expected Stop to return false for a stopped timer")
+	}
+
+	h.Advance(2 * h.Unit)
+	expectNoValue(t, timer.Chan(), "stopped timer")
+}
+
+func testTimerStopAfterFire(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(h.Unit)
+	h.Advance(h.Unit)
+	expectValue(t, timer.Chan(), "timer after duration elapsed")
+
+	if timer.Stop() {
+		t.Errorf("expected Stop to return false for a fired timer")
+	}
+}
+
+func testTimerResetActive(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(2 * h.Unit)
+	h.Advance(h.Unit)
+
+	if !timer.Reset(2 * h.Unit) {
+		t.Errorf("expected Reset to return true for an active timer")
+	}
+
+	h.Advance(h.Unit + h.Unit/2)
+	expectNoValue(t, timer.Chan(), "timer before reset duration elapsed")
+
+	h.Advance(h.Unit / 2)
+	expectValue(t, timer.Chan(), "timer after reset duration elapsed")
+}
+
+func testTimerResetFired(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(h.Unit)
+	h.Advance(h.Unit)
+	expectValue(t, timer.Chan(), "timer after duration elapsed")
+
+	if timer.Reset(h.Unit) {
+		t.Errorf("expected Reset to return false for a fired timer")
+	}
+
+	h.Advance(h.Unit)
+	expectValue(t, timer.Chan(), "timer after reset duration elapsed")
+}
+
+func testTimerResetStopped(t *testing.T, h Harness) {
+	timer := h.Clock.NewTimer(h.Unit)
+	timer.Stop()
+
+	if timer.Reset(h.Unit) {
+		t.Errorf("expected Reset to return false for a stopped timer")
+	}
+
+	h.Advance(h.Unit)
+	expectValue(t, timer.Chan(), "timer after reset duration elapsed")
+}
+
+func testAfterFunc(t *testing.T, h Harness) {
+	called := make(chan struct{}, 2)
+	h.Clock.AfterFunc(2*h.Unit, func() { called <- struct{}{} })
+
+	h.Advance(h.Unit)
+	expectNoValue(t, called, "AfterFunc before duration elapsed")
+
+	h.Advance(h.Unit)
+	expectValue(t, called, "AfterFunc after duration elapsed")
+
+	h.Advance(2 * h.Unit)
+	expectNoValue(t, called, "AfterFunc after it was called")
+}
+
+func testAfterFuncNonPositive(t *testing.T, h Harness) {
+	called := make(chan struct{}, 2)
+	h.Clock.AfterFunc(0, func() { called <- struct{}{} })
+	h.Clock.AfterFunc(-h.Unit, func() { called <- struct{}{} })
+
+	if h.DefersNonPositiveAfterFunc {
+		h.Advance(0)
+	}
+
+	expectValue(t, called, "AfterFunc(0)")
+	expectValue(t, called, "AfterFunc with negative duration")
+}
+
+func testAfterFuncStop(t *testing.T, h Harness) {
+	called := make(chan struct{}, 1)
+	timer := h.Clock.AfterFunc(2*h.Unit, func() { called <- struct{}{} })
+
+	h.Advance(h.Unit)
+	if !timer.Stop() {
+		t.Errorf("expected Stop to return true for a pending AfterFunc")
+	}
+
+	h.Advance(2 * h.Unit)
+	expectNoValue(t, called, "stopped AfterFunc")
+}
+
+func testTicker(t *testing.T, h Harness) {
+	ticker := h.Clock.NewTicker(h.Unit)
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		h.Advance(h.Unit)
+		expectValue(t, ticker.Chan(), "ticker after interval elapsed")
+	}
+}
+
+func testTickerDrops(t *testing.T, h Harness) {
+	ticker := h.Clock.NewTicker(h.Unit)
+	defer ticker.Stop()
+
+	h.Advance(3*h.Unit + h.Unit/2)
+	expectValue(t, ticker.Chan(), "ticker after several intervals elapsed")
+	expectNoValue(t, ticker.Chan(), "ticker after reading one tick for several intervals")
+}
+
+func testTickerStop(t *testing.T, h Harness) {
+	ticker := h.Clock.NewTicker(h.Unit)
+	h.Advance(h.Unit)
+	expectValue(t, ticker.Chan(), "ticker after interval elapsed")
+
+	ticker.Stop()
+	h.Advance(2 * h.Unit)
+	expectNoValue(t, ticker.Chan(), "stopped ticker")
+}
+
+func testTickerNonPositive(t *testing.T, h Harness) {
+	for _, duration := range []time.Duration{0, -h.Unit} {
+		func() {
+			defer func() {
+				if r := recover(); r != nonPositiveTickerPanic {
+					t.Errorf("expected NewTicker(%s) to panic with %q, got %v", duration, nonPositiveTickerPanic, r)
+				}
+			}()
+
+			h.Clock.NewTicker(duration)
+		}()
+	}
+}
+
+// nonPositiveTickerPanic is the value time.NewTicker panics with when given a
+// non-positive interval.
+const nonPositiveTickerPanic = "non-positive interval for NewTicker"
+
+func expectValue[T any](t *testing.T, ch <-chan T, description string) (value T, ok bool) {
+	t.Helper()
+
+	select {
+	case value := <-ch:
+		return value, true
+	case <-time.After(receiveTimeout):
+		t.Errorf("expected a value from %s", description)
+		return value, false
+	}
+}
+
+func expectNoValue[T any](t *testing.T, ch <-chan T, description string) {
+	t.Helper()
+
+	select {
+	case <-ch:
+		t.Errorf("expected no value from %s", description)
+	case <-time.After(settleTimeout):
+	}
+}
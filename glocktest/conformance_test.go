@@ -0,0 +1,49 @@
+package glocktest
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+func TestRealClockConformance(t *testing.T) {
+	RunClockConformance(t, RealHarness)
+}
+
+func TestMockClockConformance(t *testing.T) {
+	RunClockConformance(t, MockHarness)
+}
+
+func TestSynchronousMockClockConformance(t *testing.T) {
+	RunClockConformance(t, func(t *testing.T) Harness {
+		clock := glock.NewMockClockAt(time.Unix(0, 0), glock.WithSynchronousAfterFunc())
+		return Harness{Clock: clock, Advance: clock.Advance, Unit: time.Second, DefersNonPositiveAfterFunc: true}
+	})
+}
+
+func TestRecordingClockConformance(t *testing.T) {
+	RunClockConformance(t, func(t *testing.T) Harness {
+		h := MockHarness(t)
+		h.Clock = glock.NewRecordingClockFrom(h.Clock, io.Discard)
+		return h
+	})
+}
+
+func TestInstrumentedClockConformance(t *testing.T) {
+	RunClockConformance(t, func(t *testing.T) Harness {
+		h := MockHarness(t)
+		h.Clock = glock.NewInstrumentedClock(h.Clock, nopMetricsSink{})
+		return h
+	})
+}
+
+type nopMetricsSink struct{}
+
+func (nopMetricsSink) TimerStarted()                    {}
+func (nopMetricsSink) TimerStopped()                    {}
+func (nopMetricsSink) TickerStarted()                   {}
+func (nopMetricsSink) TickerStopped()                   {}
+func (nopMetricsSink) SleepObserved(_, _ time.Duration) {}
+func (nopMetricsSink) TickObserved(lag time.Duration)   {}
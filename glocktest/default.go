@@ -0,0 +1,59 @@
+package glocktest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/derision-test/glock"
+)
+
+var (
+	overrideMu    sync.Mutex
+	overrideOwner string // name of the test that last overrode the default clock
+)
+
+// OverrideDefault replaces the default clock returned by glock.Default with
+// the given clock for the duration of the test, restoring it on cleanup.
+//
+// As the default clock is process-wide, tests that override it must not run in
+// parallel with each other or with tests that use the default clock. A test
+// that overrides the clock while another test's override is still in effect
+// fails. A sequential subtest may override the clock again; the parent's clock
+// is restored when the subtest finishes.
+func OverrideDefault(t *testing.T, clock glock.Clock) {
+	t.Helper()
+	overrideDefault(t, clock)
+}
+
+// overrideT is the subset of testing.T used by overrideDefault.
+type overrideT interface {
+	Helper()
+	Name() string
+	Cleanup(f func())
+	Fatalf(format string, args ...interface{})
+}
+
+func overrideDefault(t overrideT, clock glock.Clock) {
+	t.Helper()
+
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+
+	previousOwner := overrideOwner
+	if previousOwner != "" && !strings.HasPrefix(t.Name(), previousOwner+"/") {
+		t.Fatalf("glocktest: %s cannot override the default clock while %s overrides it; tests that override the default clock cannot run in parallel", t.Name(), previousOwner)
+		return
+	}
+
+	overrideOwner = t.Name()
+	restore := glock.SetDefault(clock)
+
+	t.Cleanup(func() {
+		overrideMu.Lock()
+		defer overrideMu.Unlock()
+
+		restore()
+		overrideOwner = previousOwner
+	})
+}
@@ -0,0 +1,83 @@
+package glocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverrideDefault(t *testing.T) {
+	previous := glock.Default()
+	clock := glock.NewMockClockAt(time.Unix(100, 0))
+
+	t.Run("override", func(t *testing.T) {
+		OverrideDefault(t, clock)
+		assert.Same(t, clock, glock.Default())
+		assert.Equal(t, time.Unix(100, 0), glock.Now())
+	})
+
+	assert.Equal(t, previous, glock.Default())
+}
+
+func TestOverrideDefaultNested(t *testing.T) {
+	previous := glock.Default()
+	outer := glock.NewMockClockAt(time.Unix(100, 0))
+	inner := glock.NewMockClockAt(time.Unix(200, 0))
+
+	t.Run("outer", func(t *testing.T) {
+		OverrideDefault(t, outer)
+
+		t.Run("inner", func(t *testing.T) {
+			OverrideDefault(t, inner)
+			assert.Same(t, inner, glock.Default())
+		})
+
+		assert.Same(t, outer, glock.Default())
+	})
+
+	assert.Equal(t, previous, glock.Default())
+}
+
+func TestOverrideDefaultConcurrent(t *testing.T) {
+	previous := glock.Default()
+	first := &fakeOverrideT{name: "TestFirst"}
+	second := &fakeOverrideT{name: "TestSecond"}
+
+	overrideDefault(first, glock.NewMockClock())
+	overrideDefault(second, glock.NewMockClock())
+	assert.False(t, first.failed)
+	assert.True(t, second.failed)
+	assert.Empty(t, second.cleanups)
+
+	first.cleanup()
+	assert.Equal(t, previous, glock.Default())
+
+	overrideDefault(second, glock.NewMockClock())
+	assert.Len(t, second.cleanups, 1)
+	second.cleanup()
+	assert.Equal(t, previous, glock.Default())
+}
+
+type fakeOverrideT struct {
+	name     string
+	failed   bool
+	cleanups []func()
+}
+
+func (t *fakeOverrideT) Helper()          {}
+func (t *fakeOverrideT) Name() string     { return t.name }
+func (t *fakeOverrideT) Cleanup(f func()) { t.cleanups = append(t.cleanups, f) }
+
+func (t *fakeOverrideT) Fatalf(format string, args ...interface{}) {
+	t.failed = true
+}
+
+func (t *fakeOverrideT) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+
+	t.cleanups = nil
+}
@@ -0,0 +1,131 @@
+// Package glocktest provides helpers for testing code that uses glock clocks.
+package glocktest
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// DefaultMaxStep is the default upper bound on the size of a random step
+// taken by a Fuzzer when no event is pending.
+const DefaultMaxStep = time.Second
+
+// Fuzzer drives a MockClock with randomized advances. Step sizes are chosen
+// so that the clock sometimes lands exactly on, sometimes stops short of,
+// and sometimes overshoots the next pending deadline, and events sharing a
+// deadline are processed in a random order. All choices are derived from a
+// single seed, so a failing interleaving can be reproduced by reusing it.
+//
+// The clock runs AfterFunc callbacks in the goroutine that advances it (see
+// WithSynchronousAfterFunc), so the order in which callbacks sharing a deadline
+// run is fully determined by the seed. Timer and ticker values are sent from
+// their own goroutines, as with a plain MockClock, unless the fuzzer is created
+// with WithSynchronousDelivery.
+type Fuzzer struct {
+	// Clock is the mock clock driven by the fuzzer.
+	Clock *glock.MockClock
+
+	// MaxStep bounds the size of a random step taken when no event is
+	// pending, and the amount by which a step may overshoot the next
+	// pending deadline.
+	MaxStep time.Duration
+
+	seed int64
+	rand *rand.Rand
+}
+
+// Fuzz creates a new Fuzzer whose random choices are derived from the given
+// seed. The fuzzer's clock starts at the Unix epoch and is created with the
+// given additional options. Fuzz can be called from within the function passed
+// to a native fuzz test's f.Fuzz, with the seed supplied by the fuzzing engine.
+//
+// Passing WithSynchronousDelivery makes the order in which timer and ticker
+// values sharing a deadline are received reproducible from the seed. Each
+// advance then waits until the values it delivers are received (or their timer
+// or ticker is stopped or reset), so readers must run in goroutines other than
+// the one that drives the fuzzer, and a reader that stops receiving from a
+// timer or ticker without stopping it blocks the fuzzer forever.
+func Fuzz(seed int64, options ...glock.MockClockOption) *Fuzzer {
+	r := rand.New(rand.NewSource(seed))
+	tieOrder := rand.New(rand.NewSource(r.Int63()))
+	options = append([]glock.MockClockOption{glock.WithRandomTieOrder(tieOrder), glock.WithSynchronousAfterFunc()}, options...)
+
+	return &Fuzzer{
+		Clock:   glock.NewMockClockAt(time.Unix(0, 0), options...),
+		MaxStep: DefaultMaxStep,
+		seed:    seed,
+		rand:    r,
+	}
+}
+
+// Seed returns the seed from which the fuzzer's choices are derived.
+func (f *Fuzzer) Seed() int64 {
+	return f.seed
+}
+
+// Rand returns the fuzzer's random source. Code under test may draw from it
+// to randomize its own inputs reproducibly.
+func (f *Fuzzer) Rand() *rand.Rand {
+	return f.rand
+}
+
+// Step advances the clock by a random duration and returns it.
+func (f *Fuzzer) Step() time.Duration {
+	step := f.nextStep()
+	f.Clock.Advance(step)
+	return step
+}
+
+// Advance advances the clock by the given total duration in random steps
+// and returns the number of steps taken.
+func (f *Fuzzer) Advance(total time.Duration) int {
+	steps := 0
+	for total > 0 {
+		step := f.nextStep()
+		if step > total {
+			step = total
+		}
+
+		f.Clock.Advance(step)
+		total -= step
+		steps++
+	}
+
+	return steps
+}
+
+// nextStep chooses the size of the next step relative to the next pending
+// deadline, if any.
+func (f *Fuzzer) nextStep() time.Duration {
+	deadline, ok := f.Clock.NextDeadline()
+	if !ok {
+		return f.between(1, f.MaxStep)
+	}
+
+	remaining := f.Clock.Until(deadline)
+	if remaining <= 0 {
+		// The deadline has passed but its event has not yet been
+		// processed; nudge the clock forward by a small amount.
+		return f.between(1, f.MaxStep/100+1)
+	}
+
+	switch f.rand.Intn(4) {
+	case 0:
+		return f.between(1, remaining)
+	case 1:
+		return remaining + f.between(1, f.MaxStep)
+	}
+
+	return remaining
+}
+
+// between returns a random duration in [min, max].
+func (f *Fuzzer) between(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+
+	return min + time.Duration(f.rand.Int63n(int64(max-min)+1))
+}
@@ -0,0 +1,203 @@
+package glocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzReproducible(t *testing.T) {
+	t.Parallel()
+
+	run := func(seed int64) []time.Duration {
+		fuzzer := Fuzz(seed)
+		fuzzer.Clock.After(5 * time.Second)
+		fuzzer.Clock.After(7 * time.Second)
+
+		var steps []time.Duration
+		for i := 0; i < 20; i++ {
+			steps = append(steps, fuzzer.Step())
+		}
+
+		return steps
+	}
+
+	assert.Equal(t, run(42), run(42))
+	assert.NotEqual(t, run(42), run(43))
+}
+
+func TestFuzzLandsOnDeadlines(t *testing.T) {
+	t.Parallel()
+
+	landed := 0
+	for seed := int64(0); seed < 20; seed++ {
+		fuzzer := Fuzz(seed)
+		ch := fuzzer.Clock.After(5 * time.Second)
+
+		for len(ch) == 0 {
+			fuzzer.Step()
+		}
+
+		if fuzzer.Clock.Now().Equal(time.Unix(5, 0)) {
+			landed++
+		}
+	}
+
+	assert.True(t, landed > 0, "expected some seeds to land exactly on the deadline")
+	assert.True(t, landed < 20, "expected some seeds to overshoot the deadline")
+}
+
+func TestFuzzAdvance(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := Fuzz(1)
+	fuzzer.MaxStep = 100 * time.Millisecond
+
+	steps := fuzzer.Advance(10 * time.Second)
+	assert.True(t, steps >= 100, "expected at least 100 steps, got %d", steps)
+	assert.Equal(t, time.Unix(10, 0), fuzzer.Clock.Now())
+	assert.Equal(t, int64(1), fuzzer.Seed())
+}
+
+func TestFuzzTieOrderReproducible(t *testing.T) {
+	t.Parallel()
+
+	run := func(seed int64) string {
+		fuzzer := Fuzz(seed)
+
+		order := ""
+		for _, name := range []string{"a", "b", "c"} {
+			name := name
+			fuzzer.Clock.AfterFunc(time.Second, func() { order += name })
+		}
+
+		fuzzer.Advance(2 * time.Second)
+		return order
+	}
+
+	orders := map[string]struct{}{}
+	for seed := int64(0); seed < 20; seed++ {
+		order := run(seed)
+		orders[order] = struct{}{}
+
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, order, run(seed), "expected seed %d to reproduce the order", seed)
+		}
+	}
+
+	assert.True(t, len(orders) > 1, "expected seeds to produce different orders")
+}
+
+func TestFuzzTimerTieOrderReproducible(t *testing.T) {
+	t.Parallel()
+
+	run := func(seed int64) string {
+		fuzzer := Fuzz(seed, glock.WithSynchronousDelivery())
+		a := fuzzer.Clock.NewTimer(time.Second)
+		b := fuzzer.Clock.NewTimer(time.Second)
+
+		order := ""
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 2; i++ {
+				select {
+				case <-a.Chan():
+					order += "a"
+				case <-b.Chan():
+					order += "b"
+				}
+			}
+		}()
+
+		fuzzer.Advance(2 * time.Second)
+		<-done
+		return order
+	}
+
+	orders := map[string]struct{}{}
+	for seed := int64(0); seed < 20; seed++ {
+		order := run(seed)
+		orders[order] = struct{}{}
+
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, order, run(seed), "expected seed %d to reproduce the order", seed)
+		}
+	}
+
+	assert.True(t, len(orders) > 1, "expected seeds to produce different orders")
+}
+
+func TestFuzzAbandonedTimer(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := Fuzz(1)
+	timer := fuzzer.Clock.NewTimer(time.Second)
+	done := make(chan struct{})
+	close(done)
+
+	// The reader gives up without stopping the timer
+	go func() {
+		select {
+		case <-timer.Chan():
+		case <-done:
+		}
+	}()
+
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+		fuzzer.Advance(time.Minute)
+	}()
+
+	select {
+	case <-advanced:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected advance to return with an abandoned timer")
+	}
+}
+
+// FuzzTimeout demonstrates using a Fuzzer within a native fuzz test. An
+// operation races a timeout, and whichever finishes first decides the
+// outcome: it must complete if it finishes before the timeout and time out if
+// it finishes after, and the seed must reproduce the outcome of a tie.
+func FuzzTimeout(f *testing.F) {
+	f.Add(int64(0))
+	f.Add(int64(1))
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		run := func() (outcome string, delay time.Duration) {
+			fuzzer := Fuzz(seed)
+			delay = time.Duration(fuzzer.Rand().Int63n(int64(2 * time.Second)))
+
+			finish := func(result string) func() {
+				return func() {
+					if outcome == "" {
+						outcome = result
+					}
+				}
+			}
+
+			fuzzer.Clock.AfterFunc(time.Second, finish("timeout"))
+			fuzzer.Clock.AfterFunc(delay, finish("completed"))
+			fuzzer.Advance(2 * time.Second)
+			return outcome, delay
+		}
+
+		outcome, delay := run()
+		switch {
+		case delay < time.Second:
+			assert.Equal(t, "completed", outcome)
+		case delay > time.Second:
+			assert.Equal(t, "timeout", outcome)
+		default:
+			assert.NotEmpty(t, outcome)
+		}
+
+		again, _ := run()
+		assert.Equal(t, outcome, again, "expected seed to reproduce the outcome")
+	})
+}
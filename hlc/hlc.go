@@ -0,0 +1,211 @@
+// Package hlc implements hybrid logical clocks, which combine a physical
+// timestamp read from a glock.Clock with a logical counter to capture
+// causality between events on different nodes.
+package hlc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// Timestamp is a hybrid logical timestamp. Timestamps are ordered first by
+// their wall time and then by their logical counter.
+type Timestamp struct {
+	// WallTime is the physical component of the timestamp, in nanoseconds
+	// since the Unix epoch.
+	WallTime int64
+
+	// Logical distinguishes events that share the same wall time. When it
+	// would overflow, the clock advances the wall time by a nanosecond and
+	// resets it instead.
+	Logical uint32
+}
+
+// EncodedLen is the length of an encoded timestamp in bytes.
+const EncodedLen = 12
+
+// ErrInvalidEncoding is returned when decoding a malformed timestamp.
+var ErrInvalidEncoding = errors.New("hlc: invalid timestamp encoding")
+
+// Before returns true if ts happened before other.
+func (ts Timestamp) Before(other Timestamp) bool {
+	return ts.Compare(other) < 0
+}
+
+// Compare returns -1, 0, or 1 if ts is less than, equal to, or greater than
+// other, respectively.
+func (ts Timestamp) Compare(other Timestamp) int {
+	switch {
+	case ts.WallTime < other.WallTime:
+		return -1
+	case ts.WallTime > other.WallTime:
+		return 1
+	case ts.Logical < other.Logical:
+		return -1
+	case ts.Logical > other.Logical:
+		return 1
+	}
+
+	return 0
+}
+
+// IsZero returns true if ts is the zero timestamp.
+func (ts Timestamp) IsZero() bool {
+	return ts == Timestamp{}
+}
+
+// Time returns the physical component of the timestamp as a time.Time.
+func (ts Timestamp) Time() time.Time {
+	return time.Unix(0, ts.WallTime)
+}
+
+// next returns the smallest timestamp greater than ts.
+func (ts Timestamp) next() Timestamp {
+	if ts.Logical == math.MaxUint32 {
+		return Timestamp{WallTime: ts.WallTime + 1}
+	}
+
+	return Timestamp{WallTime: ts.WallTime, Logical: ts.Logical + 1}
+}
+
+func (ts Timestamp) String() string {
+	return fmt.Sprintf("%d.%d", ts.WallTime, ts.Logical)
+}
+
+// Encode returns the compact binary encoding of the timestamp. Encoded
+// timestamps sort bytewise in the same order as the timestamps themselves
+// (for wall times after the Unix epoch).
+func (ts Timestamp) Encode() []byte {
+	return ts.AppendEncoded(make([]byte, 0, EncodedLen))
+}
+
+// AppendEncoded appends the compact binary encoding of the timestamp to buf.
+func (ts Timestamp) AppendEncoded(buf []byte) []byte {
+	var encoded [EncodedLen]byte
+	binary.BigEndian.PutUint64(encoded[:8], uint64(ts.WallTime))
+	binary.BigEndian.PutUint32(encoded[8:], ts.Logical)
+	return append(buf, encoded[:]...)
+}
+
+// Decode parses a timestamp produced by Encode.
+func Decode(buf []byte) (Timestamp, error) {
+	if len(buf) != EncodedLen {
+		return Timestamp{}, ErrInvalidEncoding
+	}
+
+	return Timestamp{
+		WallTime: int64(binary.BigEndian.Uint64(buf[:8])),
+		Logical:  binary.BigEndian.Uint32(buf[8:]),
+	}, nil
+}
+
+// ClockOffsetError is returned by Update when a remote timestamp is further
+// ahead of the local physical clock than the configured maximum offset.
+type ClockOffsetError struct {
+	Remote    Timestamp
+	Physical  time.Time
+	MaxOffset time.Duration
+}
+
+func (e *ClockOffsetError) Error() string {
+	return fmt.Sprintf(
+		"hlc: remote timestamp %s is %s ahead of local physical time (max offset %s)",
+		e.Remote,
+		e.Remote.Time().Sub(e.Physical),
+		e.MaxOffset,
+	)
+}
+
+// Clock is a hybrid logical clock. Every timestamp returned by a Clock is
+// strictly greater than all timestamps it has previously returned or
+// observed via Update.
+type Clock struct {
+	physical  glock.Clock
+	maxOffset time.Duration
+	mu        sync.Mutex
+	last      Timestamp
+}
+
+// NewClock creates a new hybrid logical clock that reads physical time from
+// the given clock. If maxOffset is positive, Update rejects remote timestamps
+// that are more than maxOffset ahead of the local physical time.
+func NewClock(physical glock.Clock, maxOffset time.Duration) *Clock {
+	return &Clock{
+		physical:  physical,
+		maxOffset: maxOffset,
+	}
+}
+
+// MaxOffset returns the maximum tolerated offset of remote timestamps.
+func (c *Clock) MaxOffset() time.Duration {
+	return c.maxOffset
+}
+
+// Now returns a timestamp for a local or send event.
+func (c *Clock) Now() Timestamp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if wallTime := c.physical.Now().UnixNano(); wallTime > c.last.WallTime {
+		c.last = Timestamp{WallTime: wallTime}
+	} else {
+		c.last = c.last.next()
+	}
+
+	return c.last
+}
+
+// Last returns the most recent timestamp issued or observed by the clock
+// without advancing it.
+func (c *Clock) Last() Timestamp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.last
+}
+
+// Update merges a timestamp received from a remote node into the clock and
+// returns a timestamp for the receive event that is greater than both the
+// remote timestamp and every timestamp previously issued by this clock. If
+// the remote timestamp exceeds the maximum offset, the clock is not changed
+// and a *ClockOffsetError is returned.
+func (c *Clock) Update(remote Timestamp) (Timestamp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	physical := c.physical.Now()
+	wallTime := physical.UnixNano()
+
+	if c.maxOffset > 0 && remote.WallTime-wallTime > int64(c.maxOffset) {
+		return Timestamp{}, &ClockOffsetError{
+			Remote:    remote,
+			Physical:  physical,
+			MaxOffset: c.maxOffset,
+		}
+	}
+
+	switch {
+	case wallTime > c.last.WallTime && wallTime > remote.WallTime:
+		c.last = Timestamp{WallTime: wallTime}
+
+	case remote.WallTime > c.last.WallTime:
+		c.last = remote.next()
+
+	case c.last.WallTime > remote.WallTime:
+		c.last = c.last.next()
+
+	default:
+		if remote.Logical > c.last.Logical {
+			c.last.Logical = remote.Logical
+		}
+		c.last = c.last.next()
+	}
+
+	return c.last, nil
+}
@@ -0,0 +1,178 @@
+package hlc
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNow(t *testing.T) {
+	t.Parallel()
+
+	physical := glock.NewMockClockAt(time.Unix(0, 100))
+	clock := NewClock(physical, 0)
+
+	assert.Equal(t, Timestamp{WallTime: 100}, clock.Now())
+	assert.Equal(t, Timestamp{WallTime: 100, Logical: 1}, clock.Now())
+	assert.Equal(t, Timestamp{WallTime: 100, Logical: 2}, clock.Now())
+
+	physical.Advance(1)
+	assert.Equal(t, Timestamp{WallTime: 101}, clock.Now())
+
+	// Physical clock moving backwards does not move the HLC backwards
+	physical.SetCurrent(time.Unix(0, 50))
+	assert.Equal(t, Timestamp{WallTime: 101, Logical: 1}, clock.Now())
+	assert.Equal(t, Timestamp{WallTime: 101, Logical: 1}, clock.Last())
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("physical time ahead", func(t *testing.T) {
+		clock := NewClock(glock.NewMockClockAt(time.Unix(0, 100)), 0)
+
+		ts, err := clock.Update(Timestamp{WallTime: 90, Logical: 5})
+		require.Nil(t, err)
+		assert.Equal(t, Timestamp{WallTime: 100}, ts)
+	})
+	t.Run("remote ahead", func(t *testing.T) {
+		clock := NewClock(glock.NewMockClockAt(time.Unix(0, 100)), 0)
+		clock.Now()
+
+		ts, err := clock.Update(Timestamp{WallTime: 150, Logical: 5})
+		require.Nil(t, err)
+		assert.Equal(t, Timestamp{WallTime: 150, Logical: 6}, ts)
+
+		// Subsequent local events remain after the remote event
+		assert.Equal(t, Timestamp{WallTime: 150, Logical: 7}, clock.Now())
+	})
+	t.Run("last ahead", func(t *testing.T) {
+		physical := glock.NewMockClockAt(time.Unix(0, 200))
+		clock := NewClock(physical, 0)
+		clock.Now()
+		physical.SetCurrent(time.Unix(0, 100))
+
+		ts, err := clock.Update(Timestamp{WallTime: 150, Logical: 5})
+		require.Nil(t, err)
+		assert.Equal(t, Timestamp{WallTime: 200, Logical: 1}, ts)
+	})
+	t.Run("equal wall times", func(t *testing.T) {
+		physical := glock.NewMockClockAt(time.Unix(0, 200))
+		clock := NewClock(physical, 0)
+		clock.Now()
+		clock.Now()
+		physical.SetCurrent(time.Unix(0, 100))
+
+		ts, err := clock.Update(Timestamp{WallTime: 200, Logical: 5})
+		require.Nil(t, err)
+		assert.Equal(t, Timestamp{WallTime: 200, Logical: 6}, ts)
+
+		ts, err = clock.Update(Timestamp{WallTime: 200, Logical: 2})
+		require.Nil(t, err)
+		assert.Equal(t, Timestamp{WallTime: 200, Logical: 7}, ts)
+	})
+	t.Run("max offset", func(t *testing.T) {
+		clock := NewClock(glock.NewMockClockAt(time.Unix(100, 0)), time.Second)
+		before := clock.Now()
+
+		_, err := clock.Update(Timestamp{WallTime: time.Unix(101, 0).UnixNano()})
+		require.Nil(t, err)
+
+		_, err = clock.Update(Timestamp{WallTime: time.Unix(102, 1).UnixNano()})
+		offsetErr, ok := err.(*ClockOffsetError)
+		require.True(t, ok, "expected ClockOffsetError, got %v", err)
+		assert.Equal(t, time.Second, offsetErr.MaxOffset)
+		assert.Equal(t, time.Unix(101, 0).UnixNano(), clock.Last().WallTime)
+		assert.True(t, before.Before(clock.Last()))
+	})
+}
+
+func TestLogicalOverflow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("now", func(t *testing.T) {
+		clock := NewClock(glock.NewMockClockAt(time.Unix(0, 100)), 0)
+		clock.last = Timestamp{WallTime: 100, Logical: math.MaxUint32}
+
+		// The counter carries into the wall time rather than wrapping
+		assert.Equal(t, Timestamp{WallTime: 101}, clock.Now())
+		assert.Equal(t, Timestamp{WallTime: 101, Logical: 1}, clock.Now())
+	})
+	t.Run("update", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			last   Timestamp
+			remote Timestamp
+		}{
+			{"remote ahead", Timestamp{WallTime: 50}, Timestamp{WallTime: 200, Logical: math.MaxUint32}},
+			{"local ahead", Timestamp{WallTime: 200, Logical: math.MaxUint32}, Timestamp{WallTime: 50}},
+			{"equal wall times", Timestamp{WallTime: 200, Logical: 3}, Timestamp{WallTime: 200, Logical: math.MaxUint32}},
+		} {
+			clock := NewClock(glock.NewMockClockAt(time.Unix(0, 100)), 0)
+			clock.last = tc.last
+
+			ts, err := clock.Update(tc.remote)
+			require.Nil(t, err, tc.name)
+			assert.Equal(t, Timestamp{WallTime: 201}, ts, tc.name)
+			assert.True(t, tc.last.Before(ts) && tc.remote.Before(ts), tc.name)
+		}
+	})
+}
+
+func TestSkewedNodes(t *testing.T) {
+	t.Parallel()
+
+	// Node b's physical clock lags node a's by five seconds
+	physicalA := glock.NewMockClockAt(time.Unix(100, 0))
+	physicalB := glock.NewMockClockAt(time.Unix(95, 0))
+	a := NewClock(physicalA, 10*time.Second)
+	b := NewClock(physicalB, 10*time.Second)
+
+	send := a.Now()
+	receive, err := b.Update(send)
+	require.Nil(t, err)
+	assert.True(t, send.Before(receive))
+
+	physicalB.Advance(time.Second)
+	reply := b.Now()
+	assert.True(t, receive.Before(reply))
+
+	physicalA.Advance(time.Millisecond)
+	ack, err := a.Update(reply)
+	require.Nil(t, err)
+	assert.True(t, reply.Before(ack))
+	assert.Equal(t, time.Unix(100, int64(time.Millisecond)).UnixNano(), ack.WallTime)
+}
+
+func TestEncoding(t *testing.T) {
+	t.Parallel()
+
+	timestamps := []Timestamp{
+		{WallTime: 1, Logical: 0},
+		{WallTime: 1, Logical: 1},
+		{WallTime: 1, Logical: 256},
+		{WallTime: 2, Logical: 0},
+		{WallTime: time.Unix(1700000000, 0).UnixNano(), Logical: 3},
+	}
+
+	for i, ts := range timestamps {
+		encoded := ts.Encode()
+		assert.Len(t, encoded, EncodedLen)
+
+		decoded, err := Decode(encoded)
+		require.Nil(t, err)
+		assert.Equal(t, ts, decoded)
+
+		if i > 0 {
+			assert.Equal(t, -1, bytes.Compare(timestamps[i-1].Encode(), encoded))
+		}
+	}
+
+	_, err := Decode([]byte{1, 2, 3})
+	assert.Equal(t, ErrInvalidEncoding, err)
+}
@@ -0,0 +1,176 @@
+package glock
+
+import "time"
+
+// EventKind identifies what created an event reported to MockClock hooks.
+type EventKind int
+
+const (
+	// AfterEvent is an event created by After or Sleep.
+	AfterEvent EventKind = iota
+
+	// TimerEvent is an event created by NewTimer.
+	TimerEvent
+
+	// AfterFuncEvent is an event created by AfterFunc.
+	AfterFuncEvent
+
+	// TickerEvent is an event created by NewTicker, NewTickerImmediate,
+	// NewJitteredTicker, or NewPolicyTicker.
+	TickerEvent
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case AfterEvent:
+		return "after"
+	case TimerEvent:
+		return "timer"
+	case AfterFuncEvent:
+		return "afterfunc"
+	case TickerEvent:
+		return "ticker"
+	default:
+		return "unknown"
+	}
+}
+
+// PendingEvent describes an After channel, timer, or ticker that was scheduled
+// on a MockClock. See MockClock.OnRegister.
+type PendingEvent struct {
+	// ID identifies the After channel, timer, or ticker. It is the same for
+	// every event of a timer that is reset or a ticker that ticks repeatedly.
+	ID uint64
+
+	// Kind is the kind of the event.
+	Kind EventKind
+
+	// Deadline is the time at which the event is due to fire.
+	Deadline time.Time
+}
+
+// FiredEvent describes an After channel, timer, or ticker of a MockClock that
+// reached its deadline. See MockClock.OnFire.
+type FiredEvent struct {
+	// ID identifies the After channel, timer, or ticker, matching the ID of
+	// the PendingEvent that scheduled it.
+	ID uint64
+
+	// Kind is the kind of the event.
+	Kind EventKind
+
+	// Deadline is the time at which the event was due to fire.
+	Deadline time.Time
+
+	// Time is the clock's internal time when the event fired.
+	Time time.Time
+}
+
+// hooks holds the functions registered with a MockClock's hook methods.
+type hooks struct {
+	onAdvance  []func(old, new time.Time)
+	onRegister []func(PendingEvent)
+	onFire     []func(FiredEvent)
+}
+
+// eventID is embedded in each subscriber to identify it in the events passed
+// to hooks. It is assigned when the subscriber is first registered. The fired
+// deadline is recorded so that a subscriber whose goroutine has not yet moved
+// past a deadline is not reported again by a later advance.
+type eventID struct {
+	id       uint64
+	fired    bool
+	deadline time.Time
+}
+
+func (e *eventID) ident() *eventID {
+	return e
+}
+
+// OnAdvance registers a function that is called with the clock's previous and
+// new internal time each time the time is set by Advance, BlockingAdvance, or
+// SetCurrent.
+//
+// Hooks are called without the clock's lock held, so they may call back into
+// the clock, and before the method that triggered them returns. Hooks may be
+// called concurrently when the clock is used from several goroutines.
+func (c *MockClock) OnAdvance(f func(old, new time.Time)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.hooks.onAdvance = append(c.hooks.onAdvance, f)
+}
+
+// OnRegister registers a function that is called each time an After channel,
+// timer, or ticker is scheduled on the clock, including when a timer is reset.
+// See OnAdvance for when hooks are called.
+func (c *MockClock) OnRegister(f func(PendingEvent)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.hooks.onRegister = append(c.hooks.onRegister, f)
+}
+
+// OnFire registers a function that is called each time an After channel, timer,
+// or ticker of the clock reaches its deadline. A ticker that misses several
+// ticks in a single advance fires once. See OnAdvance for when hooks are called.
+func (c *MockClock) OnFire(f func(FiredEvent)) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.hooks.onFire = append(c.hooks.onFire, f)
+}
+
+// notifyAdvance queues a call to the OnAdvance hooks. This method assumes the
+// lock is held.
+func (a *advanceable) notifyAdvance(old, new time.Time) {
+	for _, f := range a.hooks.onAdvance {
+		f := f
+		a.notifications = append(a.notifications, func() { f(old, new) })
+	}
+}
+
+// announce assigns an identifier to a subscriber that has just been scheduled
+// and queues calls to the OnRegister hooks, and to the OnFire hooks if its
+// deadline has already passed. This method assumes the lock is held.
+func (a *advanceable) announce(s subscriber) {
+	id := s.ident()
+	if id.id == 0 {
+		a.lastID++
+		id.id = a.lastID
+	}
+
+	id.fired = false
+
+	deadline, ok := s.next()
+	if !ok {
+		return
+	}
+
+	event := PendingEvent{ID: id.id, Kind: s.kind(), Deadline: deadline}
+	for _, f := range a.hooks.onRegister {
+		f := f
+		a.notifications = append(a.notifications, func() { f(event) })
+	}
+
+	if !a.now.Before(deadline) {
+		a.notifyFire(s, deadline, a.now)
+	}
+}
+
+// notifyFire queues calls to the OnFire hooks for a subscriber that reached
+// the given deadline at the given time, unless that deadline was already
+// reported. This method assumes the lock is held.
+func (a *advanceable) notifyFire(s subscriber, deadline, now time.Time) {
+	id := s.ident()
+	if id.fired && id.deadline.Equal(deadline) {
+		return
+	}
+	id.fired, id.deadline = true, deadline
+
+	event := FiredEvent{ID: id.id, Kind: s.kind(), Deadline: deadline, Time: now}
+	for _, f := range a.hooks.onFire {
+		f := f
+		a.notifications = append(a.notifications, func() { f(event) })
+	}
+}
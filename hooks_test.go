@@ -0,0 +1,234 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnAdvance(t *testing.T) {
+	t.Parallel()
+
+	type advance struct{ old, new time.Time }
+	var advances []advance
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnAdvance(func(old, new time.Time) { advances = append(advances, advance{old, new}) })
+
+	clock.Advance(time.Second)
+	clock.SetCurrent(time.Unix(5, 0))
+	clock.After(time.Second)
+	clock.BlockingAdvance(time.Second)
+
+	assert.Equal(t, []advance{
+		{time.Unix(0, 0), time.Unix(1, 0)},
+		{time.Unix(1, 0), time.Unix(5, 0)},
+		{time.Unix(5, 0), time.Unix(6, 0)},
+	}, advances)
+}
+
+func TestOnRegister(t *testing.T) {
+	t.Parallel()
+
+	var events []PendingEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnRegister(func(e PendingEvent) { events = append(events, e) })
+
+	clock.After(time.Second)
+	timer := clock.NewTimer(2 * time.Second)
+	clock.AfterFunc(3*time.Second, func() {})
+	ticker := clock.NewTicker(4 * time.Second)
+	policyTicker := clock.NewPolicyTicker(5*time.Second, DropMissedTicks)
+	timer.Reset(6 * time.Second)
+	ticker.Stop()
+	policyTicker.Stop()
+
+	assert.Equal(t, []PendingEvent{
+		{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0)},
+		{ID: 2, Kind: TimerEvent, Deadline: time.Unix(2, 0)},
+		{ID: 3, Kind: AfterFuncEvent, Deadline: time.Unix(3, 0)},
+		{ID: 4, Kind: TickerEvent, Deadline: time.Unix(4, 0)},
+		{ID: 5, Kind: TickerEvent, Deadline: time.Unix(5, 0)},
+		{ID: 2, Kind: TimerEvent, Deadline: time.Unix(6, 0)},
+	}, events)
+}
+
+func TestOnFire(t *testing.T) {
+	t.Parallel()
+
+	var events []FiredEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+	clock.OnFire(func(e FiredEvent) { events = append(events, e) })
+
+	after := clock.After(time.Second)
+	clock.AfterFunc(2*time.Second, func() {})
+	clock.AfterFunc(3*time.Second, func() {}).Stop()
+
+	clock.Advance(1500 * time.Millisecond)
+	assert.Equal(t, []FiredEvent{
+		{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0), Time: time.Unix(1, 500000000)},
+	}, events)
+	eventually(t, chanReceives(after, time.Unix(1, 0)))
+
+	// Stopped timers never fire
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, []FiredEvent{
+		{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0), Time: time.Unix(1, 500000000)},
+		{ID: 2, Kind: AfterFuncEvent, Deadline: time.Unix(2, 0), Time: time.Unix(6, 500000000)},
+	}, events)
+}
+
+func TestOnFireImmediate(t *testing.T) {
+	t.Parallel()
+
+	var events []FiredEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnFire(func(e FiredEvent) { events = append(events, e) })
+
+	after := clock.After(0)
+	ticker := clock.NewTickerImmediate(time.Second)
+	defer ticker.Stop()
+
+	assert.Equal(t, []FiredEvent{
+		{ID: 1, Kind: AfterEvent, Deadline: time.Unix(0, 0), Time: time.Unix(0, 0)},
+		{ID: 2, Kind: TickerEvent, Deadline: time.Unix(0, 0), Time: time.Unix(0, 0)},
+	}, events)
+	eventually(t, chanReceives(after, time.Unix(0, 0)))
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(0, 0)))
+}
+
+func TestOnFireTimer(t *testing.T) {
+	t.Parallel()
+
+	var events []FiredEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnFire(func(e FiredEvent) { events = append(events, e) })
+
+	timer := clock.NewTimer(time.Second)
+	received := make(chan time.Time, 1)
+	go func() { received <- <-timer.Chan() }()
+
+	// The timer's goroutine may not have observed the deadline before the
+	// later advances; the firing is reported once regardless
+	clock.Advance(time.Second)
+	clock.Advance(time.Millisecond)
+	clock.Advance(time.Millisecond)
+
+	assert.Equal(t, []FiredEvent{
+		{ID: 1, Kind: TimerEvent, Deadline: time.Unix(1, 0), Time: time.Unix(1, 0)},
+	}, events)
+	eventually(t, func() bool { return !chanDoesNotReceive(received)() })
+}
+
+func TestOnFireTicker(t *testing.T) {
+	t.Parallel()
+
+	var events []FiredEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnFire(func(e FiredEvent) { events = append(events, e) })
+
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	received := make(chan time.Time, 10)
+	go func() {
+		for i := 0; i < 2; i++ {
+			received <- <-ticker.Chan()
+		}
+	}()
+
+	// Missed ticks are not reported, and a tick is reported once even if
+	// the ticker's goroutine has not observed it before the next advance
+	clock.Advance(3500 * time.Millisecond)
+	clock.Advance(time.Millisecond)
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, []FiredEvent{
+		{ID: 1, Kind: TickerEvent, Deadline: time.Unix(1, 0), Time: time.Unix(3, 500000000)},
+	}, events)
+	eventually(t, chanReceives(received, time.Unix(1, 0)))
+
+	clock.Advance(500 * time.Millisecond)
+	clock.Advance(time.Millisecond)
+	assert.Len(t, events, 2)
+	eventually(t, chanReceives(received, time.Unix(4, 0)))
+}
+
+func TestHooksCallBackIntoClock(t *testing.T) {
+	t.Parallel()
+
+	var times []time.Time
+
+	clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+	clock.OnFire(func(e FiredEvent) {
+		// Schedule a side effect at an exact virtual moment
+		clock.AfterFunc(time.Second, func() { times = append(times, clock.Now()) })
+	})
+
+	clock.After(time.Second)
+	clock.Advance(time.Second)
+	assert.Empty(t, times)
+
+	clock.Advance(time.Second)
+	assert.Equal(t, []time.Time{time.Unix(2, 0)}, times)
+}
+
+func TestHooksRunByTriggeringCall(t *testing.T) {
+	t.Parallel()
+
+	var fired []time.Time
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.OnFire(func(e FiredEvent) {
+		fired = append(fired, e.Deadline)
+
+		if e.Deadline.Equal(time.Unix(1, 0)) {
+			// A call made by another goroutine while the advancing goroutine
+			// is still dispatching must make its own hook calls before it
+			// returns, and must not make the ones queued by the advance
+			seen := make(chan []time.Time)
+			go func() {
+				clock.After(0)
+				seen <- append([]time.Time(nil), fired...)
+			}()
+
+			assert.Equal(t, []time.Time{time.Unix(1, 0), time.Unix(3, 0)}, <-seen)
+		}
+	})
+
+	clock.After(time.Second)
+	clock.After(2 * time.Second)
+	clock.Advance(3 * time.Second)
+	assert.Equal(t, []time.Time{time.Unix(1, 0), time.Unix(3, 0), time.Unix(2, 0)}, fired)
+}
+
+func TestOnRegisterRestore(t *testing.T) {
+	t.Parallel()
+
+	var events []PendingEvent
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.After(time.Second)
+	snap := clock.Snapshot()
+
+	clock.OnRegister(func(e PendingEvent) { events = append(events, e) })
+	clock.Restore(snap)
+
+	assert.Equal(t, []PendingEvent{
+		{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0)},
+	}, events)
+}
+
+func TestEventKindString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "after", AfterEvent.String())
+	assert.Equal(t, "timer", TimerEvent.String())
+	assert.Equal(t, "afterfunc", AfterFuncEvent.String())
+	assert.Equal(t, "ticker", TickerEvent.String())
+	assert.Equal(t, "unknown", EventKind(-1).String())
+}
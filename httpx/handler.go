@@ -0,0 +1,145 @@
+// Package httpx provides HTTP server and client timeouts that are measured by
+// a glock.Clock rather than by the runtime.
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// defaultTimeoutBody is the response body written by a TimeoutHandler with an
+// empty message. It matches the body written by http.TimeoutHandler.
+const defaultTimeoutBody = "<html><head><title>Timeout</title></head><body><h1>Timeout</h1></body></html>"
+
+// TimeoutHandler mimics http.TimeoutHandler, but measures the time limit with
+// the given clock. The handler h is run with a request context that is
+// canceled when the limit elapses, at which point a 503 Service Unavailable
+// response with the given message is written. Writes made by h after the
+// limit has elapsed fail with http.ErrHandlerTimeout.
+func TimeoutHandler(h http.Handler, clock glock.Clock, dt time.Duration, msg string) http.Handler {
+	if msg == "" {
+		msg = defaultTimeoutBody
+	}
+
+	return &timeoutHandler{handler: h, clock: clock, dt: dt, body: msg}
+}
+
+type timeoutHandler struct {
+	handler http.Handler
+	clock   glock.Clock
+	dt      time.Duration
+	body    string
+}
+
+func (h *timeoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := glock.ContextWithTimeout(r.Context(), h.clock, h.dt)
+	defer cancel()
+	r = r.WithContext(ctx)
+
+	tw := &timeoutWriter{header: make(http.Header)}
+	done := make(chan struct{})
+	panics := make(chan interface{}, 1)
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panics <- p
+			}
+		}()
+
+		h.handler.ServeHTTP(tw, r)
+		close(done)
+	}()
+
+	select {
+	case p := <-panics:
+		panic(p)
+
+	case <-done:
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+
+		dst := w.Header()
+		for key, values := range tw.header {
+			dst[key] = values
+		}
+
+		if !tw.wroteHeader {
+			tw.code = http.StatusOK
+		}
+
+		w.WriteHeader(tw.code)
+		_, _ = w.Write(tw.buf.Bytes())
+
+	case <-ctx.Done():
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		if err := ctx.Err(); err == context.DeadlineExceeded {
+			_, _ = io.WriteString(w, h.body)
+			tw.err = http.ErrHandlerTimeout
+		} else {
+			tw.err = err
+		}
+	}
+}
+
+// timeoutWriter buffers the response of the wrapped handler until it either
+// completes or times out.
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	buf         bytes.Buffer
+	code        int
+	wroteHeader bool
+	err         error
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.err != nil {
+		return 0, tw.err
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+
+	return tw.buf.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	if code < 100 || code > 999 {
+		panic(fmt.Sprintf("invalid WriteHeader code %v", code))
+	}
+
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.err != nil || tw.wroteHeader {
+		return
+	}
+
+	tw.writeHeader(code)
+}
+
+// writeHeader records the status code. This method assumes the lock is held.
+func (tw *timeoutWriter) writeHeader(code int) {
+	tw.wroteHeader = true
+	tw.code = code
+}
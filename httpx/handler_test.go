@@ -0,0 +1,86 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutHandler(t *testing.T) {
+	clock := glock.NewMockClock()
+	release := make(chan struct{})
+	writeErrs := make(chan error, 1)
+
+	handler := TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		<-release
+		_, err := w.Write([]byte("late"))
+		writeErrs <- err
+	}), clock, time.Second, "too slow")
+
+	recorder := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+		close(done)
+	}()
+
+	clock.BlockingAdvance(time.Second)
+	<-done
+	close(release)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "too slow", recorder.Body.String())
+	assert.Equal(t, http.ErrHandlerTimeout, <-writeErrs)
+}
+
+func TestTimeoutHandlerDefaultMessage(t *testing.T) {
+	clock := glock.NewMockClock()
+	handler := TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}), clock, time.Second, "")
+
+	recorder := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+		close(done)
+	}()
+
+	clock.BlockingAdvance(time.Second)
+	<-done
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, defaultTimeoutBody, recorder.Body.String())
+}
+
+func TestTimeoutHandlerCompletes(t *testing.T) {
+	clock := glock.NewMockClock()
+	handler := TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = io.WriteString(w, "hello")
+	}), clock, time.Second, "too slow")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusTeapot, recorder.Code)
+	assert.Equal(t, "yes", recorder.Header().Get("X-Test"))
+	assert.Equal(t, "hello", recorder.Body.String())
+}
+
+func TestTimeoutHandlerPanics(t *testing.T) {
+	handler := TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("oops")
+	}), glock.NewMockClock(), time.Second, "")
+
+	assert.PanicsWithValue(t, "oops", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	})
+}
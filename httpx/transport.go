@@ -0,0 +1,193 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// ErrIdleTimeout is returned when no progress is made on a request for longer
+// than the idle timeout of a RoundTripper.
+var ErrIdleTimeout = errors.New("httpx: idle timeout exceeded")
+
+// Options configures a RoundTripper.
+type Options struct {
+	// Timeout limits the time taken by a request, including reading the
+	// response body, like http.Client.Timeout. A zero value means requests
+	// do not time out.
+	Timeout time.Duration
+
+	// IdleTimeout limits the time spent waiting for the response headers
+	// and between reads of the response body that return data. A zero
+	// value means requests do not time out while idle. As a context deadline
+	// cannot be extended, the idle timeout is enforced by a timer of the clock
+	// (restarted on progress) that cancels the request's context, rather than
+	// by glock.ContextWithTimeout.
+	IdleTimeout time.Duration
+}
+
+// roundTripper enforces the timeouts of its options on requests made by a
+// wrapped http.RoundTripper.
+type roundTripper struct {
+	base    http.RoundTripper
+	clock   glock.Clock
+	options Options
+}
+
+// NewRoundTripper wraps the given round tripper (or http.DefaultTransport, if
+// nil) so that the timeouts in the given options are enforced with the given
+// clock. Requests that exceed Timeout fail with an error wrapping
+// context.DeadlineExceeded; requests that exceed IdleTimeout fail with
+// ErrIdleTimeout.
+func NewRoundTripper(base http.RoundTripper, clock glock.Clock, options Options) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &roundTripper{base: base, clock: clock, options: options}
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	t := &timeouts{cancel: func() {}, cancelIdle: func() {}}
+
+	if rt.options.Timeout > 0 {
+		ctx, t.cancel = glock.ContextWithTimeout(ctx, rt.clock, rt.options.Timeout)
+		t.deadline = ctx
+	}
+
+	if rt.options.IdleTimeout > 0 {
+		ctx, t.cancelIdle = context.WithCancel(ctx)
+		t.idle = newIdleTimer(rt.clock, rt.options.IdleTimeout, t.cancelIdle)
+	}
+
+	resp, err := rt.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		t.release()
+		return nil, t.translate(err)
+	}
+
+	t.idle.reset()
+	resp.Body = &body{ReadCloser: resp.Body, timeouts: t}
+	return resp, nil
+}
+
+// timeouts holds the timers of a single request.
+type timeouts struct {
+	deadline   context.Context
+	cancel     context.CancelFunc
+	cancelIdle context.CancelFunc
+	idle       *idleTimer
+}
+
+// translate replaces an error caused by an exceeded timeout with the error
+// documented for that timeout. The wrapped round tripper may only report that
+// the request context was canceled.
+func (t *timeouts) translate(err error) error {
+	if t.idle.expired() {
+		return ErrIdleTimeout
+	}
+
+	if t.deadline != nil && t.deadline.Err() == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+// release stops the timers of the request and cancels its contexts.
+func (t *timeouts) release() {
+	t.idle.stop()
+	t.cancelIdle()
+	t.cancel()
+}
+
+// body releases the timers of a request once its response body is closed.
+type body struct {
+	io.ReadCloser
+	timeouts *timeouts
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timeouts.idle.reset()
+	}
+
+	if err != nil && err != io.EOF {
+		err = b.timeouts.translate(err)
+	}
+
+	return n, err
+}
+
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	b.timeouts.release()
+	return err
+}
+
+// idleTimer cancels a request when it is not reset within its duration. The
+// methods of a nil idleTimer are no-ops.
+type idleTimer struct {
+	duration time.Duration
+	mu       sync.Mutex
+	timer    glock.Timer
+	fired    bool
+}
+
+func newIdleTimer(clock glock.Clock, duration time.Duration, cancel context.CancelFunc) *idleTimer {
+	t := &idleTimer{duration: duration}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timer = clock.AfterFunc(duration, func() {
+		t.mu.Lock()
+		t.fired = true
+		t.mu.Unlock()
+
+		cancel()
+	})
+
+	return t
+}
+
+// reset restarts the timer, unless it has already fired.
+func (t *idleTimer) reset() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.fired {
+		t.timer.Reset(t.duration)
+	}
+}
+
+func (t *idleTimer) stop() {
+	if t == nil {
+		return
+	}
+
+	t.timer.Stop()
+}
+
+// expired returns true if the timer fired.
+func (t *idleTimer) expired() bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.fired
+}
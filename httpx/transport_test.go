@@ -0,0 +1,200 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripperTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	clock := glock.NewMockClock()
+	client := &http.Client{Transport: NewRoundTripper(server.Client().Transport, clock, Options{Timeout: time.Second})}
+
+	errs := getAsync(client, server.URL)
+	clock.BlockingAdvance(time.Second)
+	err := receiveError(t, errs)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestRoundTripperTimeoutReadingBody(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "partial")
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	clock := glock.NewMockClock()
+	client := &http.Client{Transport: NewRoundTripper(server.Client().Transport, clock, Options{Timeout: time.Second})}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(resp.Body)
+		errs <- err
+	}()
+
+	clock.BlockingAdvance(time.Second)
+	err = receiveError(t, errs)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestRoundTripperIdleTimeout(t *testing.T) {
+	release := make(chan struct{})
+	progress := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 2; i++ {
+			_, _ = io.WriteString(w, "chunk")
+			w.(http.Flusher).Flush()
+			progress <- struct{}{}
+		}
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	clock := glock.NewMockClock()
+	client := &http.Client{Transport: NewRoundTripper(server.Client().Transport, clock, Options{IdleTimeout: time.Second})}
+
+	go func() { <-progress }()
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(resp.Body, buf)
+	require.NoError(t, err)
+
+	// Progress resets the idle timer
+	clock.Advance(900 * time.Millisecond)
+	<-progress
+	_, err = io.ReadFull(resp.Body, buf)
+	require.NoError(t, err)
+	clock.Advance(900 * time.Millisecond)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := resp.Body.Read(buf)
+		errs <- err
+	}()
+
+	clock.Advance(50 * time.Millisecond)
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected result %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	assert.Equal(t, ErrIdleTimeout, receiveError(t, errs))
+}
+
+func TestRoundTripperIdleTimeoutReleasesContext(t *testing.T) {
+	var contexts []context.Context
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		contexts = append(contexts, req.Context())
+		if len(contexts) > 1 {
+			return nil, errors.New("failed")
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("hello"))}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+
+	rt := NewRoundTripper(base, glock.NewMockClock(), Options{IdleTimeout: time.Second})
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.NoError(t, contexts[0].Err())
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, context.Canceled, contexts[0].Err())
+
+	_, err = rt.RoundTrip(req)
+	assert.Error(t, err)
+	assert.Equal(t, context.Canceled, contexts[1].Err())
+}
+
+func TestRoundTripperNoTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer server.Close()
+
+	clock := glock.NewMockClock()
+	client := &http.Client{Transport: NewRoundTripper(server.Client().Transport, clock, Options{Timeout: time.Second, IdleTimeout: time.Second})}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+}
+
+func getAsync(client *http.Client, url string) <-chan error {
+	errs := make(chan error, 1)
+	go func() {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		errs <- err
+	}()
+
+	return errs
+}
+
+func receiveError(t *testing.T, errs <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(time.Second):
+		t.Fatalf("expected request to fail")
+		return nil
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,196 @@
+package glock
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricsSink receives measurements from an InstrumentedClock. Methods may be
+// called concurrently and should not block.
+type MetricsSink interface {
+	// TimerStarted is called when a timer (including those backing After
+	// and AfterFunc) is created, or reset after it fired or was stopped.
+	TimerStarted()
+
+	// TimerStopped is called when a started timer fires or is stopped.
+	TimerStopped()
+
+	// TickerStarted is called when a ticker is created.
+	TickerStarted()
+
+	// TickerStopped is called when a ticker is stopped.
+	TickerStopped()
+
+	// SleepObserved is called when a Sleep returns with the requested and
+	// the actual duration of the sleep.
+	SleepObserved(requested, actual time.Duration)
+
+	// TickObserved is called when a tick is received from a ticker with the
+	// time elapsed between the tick and its receipt.
+	TickObserved(lag time.Duration)
+}
+
+// InstrumentedClock is an implementation of Clock that wraps another clock and
+// reports the number of live timers and tickers, the actual duration of
+// sleeps, and the lag between ticks and their receipt to a MetricsSink.
+type InstrumentedClock struct {
+	base Clock
+	sink MetricsSink
+}
+
+var _ Clock = &InstrumentedClock{}
+
+// NewInstrumentedClock creates a new InstrumentedClock wrapping the given clock.
+func NewInstrumentedClock(base Clock, sink MetricsSink) *InstrumentedClock {
+	return &InstrumentedClock{base: base, sink: sink}
+}
+
+// Now returns the underlying clock's current time.
+func (c *InstrumentedClock) Now() time.Time {
+	return c.base.Now()
+}
+
+// After returns a channel that receives the current time after the given
+// duration elapses. The pending value counts as a live timer.
+func (c *InstrumentedClock) After(duration time.Duration) <-chan time.Time {
+	return c.NewTimer(duration).Chan()
+}
+
+// Sleep blocks for the given duration and reports how long it actually took.
+func (c *InstrumentedClock) Sleep(duration time.Duration) {
+	start := c.base.Now()
+	c.base.Sleep(duration)
+	c.sink.SleepObserved(duration, c.base.Since(start))
+}
+
+// Since returns the time elapsed since t.
+func (c *InstrumentedClock) Since(t time.Time) time.Duration {
+	return c.base.Since(t)
+}
+
+// Until returns the duration until t.
+func (c *InstrumentedClock) Until(t time.Time) time.Duration {
+	return c.base.Until(t)
+}
+
+// NewTicker creates a new Ticker on the underlying clock that reports the lag
+// between each tick and its receipt.
+func (c *InstrumentedClock) NewTicker(duration time.Duration) Ticker {
+	t := &instrumentedTicker{
+		clock:  c,
+		ticker: c.base.NewTicker(duration),
+		ch:     make(chan time.Time),
+		done:   make(chan struct{}),
+	}
+
+	c.sink.TickerStarted()
+	go t.process()
+	return t
+}
+
+// NewTimer creates a new Timer on the underlying clock that counts as live
+// until it fires or is stopped.
+func (c *InstrumentedClock) NewTimer(duration time.Duration) Timer {
+	ch := make(chan time.Time, 1)
+
+	return c.newTimer(duration, ch, func() {
+		select {
+		case ch <- c.base.Now():
+		default:
+		}
+	})
+}
+
+// AfterFunc creates a new Timer on the underlying clock that calls f in its
+// own goroutine and counts as live until it fires or is stopped.
+func (c *InstrumentedClock) AfterFunc(duration time.Duration, f func()) Timer {
+	return c.newTimer(duration, nil, f)
+}
+
+func (c *InstrumentedClock) newTimer(duration time.Duration, ch chan time.Time, deliver func()) Timer {
+	t := &instrumentedTimer{clock: c, ch: ch}
+	c.sink.TimerStarted()
+
+	t.timer = c.base.AfterFunc(duration, func() {
+		t.finish()
+		deliver()
+	})
+
+	return t
+}
+
+// instrumentedTimer reports each arming of the underlying timer as started
+// once, and as stopped once, when it fires or is stopped. An arm that is still
+// pending when the timer is reset is carried over to the new deadline.
+type instrumentedTimer struct {
+	clock *InstrumentedClock
+	ch    chan time.Time
+	timer Timer
+	mu    sync.Mutex
+}
+
+func (t *instrumentedTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *instrumentedTimer) Reset(duration time.Duration) bool {
+	// The lock is held so that the new arm cannot be reported as stopped
+	// (by finish) before it is reported as started
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer.Reset(duration) {
+		// The pending arm continues with the new deadline
+		return true
+	}
+
+	// The previous arm has fired or was stopped, and has been or will be
+	// reported as stopped by its callback or by Stop
+	t.clock.sink.TimerStarted()
+	return false
+}
+
+func (t *instrumentedTimer) Stop() bool {
+	if !t.timer.Stop() {
+		return false
+	}
+
+	t.clock.sink.TimerStopped()
+	return true
+}
+
+// finish reports that an arm of the timer fired.
+func (t *instrumentedTimer) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.clock.sink.TimerStopped()
+}
+
+type instrumentedTicker struct {
+	clock  *InstrumentedClock
+	ticker Ticker
+	ch     chan time.Time
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (t *instrumentedTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *instrumentedTicker) Stop() {
+	t.once.Do(func() {
+		t.ticker.Stop()
+		close(t.done)
+		t.clock.sink.TickerStopped()
+	})
+}
+
+// process forwards ticks from the underlying ticker over an unbuffered channel
+// so that the time of their receipt can be observed.
+func (t *instrumentedTicker) process() {
+	forwardLatestTick(t.ticker.Chan(), t.ch, t.done, nil, func(now time.Time) {
+		t.clock.sink.TickObserved(t.clock.base.Since(now))
+	})
+}
@@ -0,0 +1,182 @@
+package glock
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentedClockTimers(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0))
+	sink := &testMetricsSink{}
+	clock := NewInstrumentedClock(base, sink)
+
+	timer := clock.NewTimer(time.Second)
+	after := clock.After(2 * time.Second)
+	called := make(chan struct{})
+	clock.AfterFunc(3*time.Second, func() { close(called) })
+	assert.Equal(t, 3, sink.liveTimers())
+
+	base.Advance(time.Second)
+	eventually(t, chanReceives(timer.Chan(), time.Unix(1, 0)))
+	assert.Equal(t, 2, sink.liveTimers())
+
+	// Resetting a fired timer makes it live again
+	timer.Reset(time.Second)
+	assert.Equal(t, 3, sink.liveTimers())
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+	assert.Equal(t, 2, sink.liveTimers())
+
+	base.Advance(2 * time.Second)
+	eventually(t, chanReceives(after, time.Unix(3, 0)))
+	eventually(t, chanClosed(called))
+	assert.Equal(t, 0, sink.liveTimers())
+	assert.Equal(t, 4, sink.timersStarted())
+}
+
+func TestInstrumentedClockTimerResetBeforeCallback(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+	sink := &testMetricsSink{}
+	clock := NewInstrumentedClock(base, sink)
+
+	// Callbacks sharing a deadline run in registration order, so the timer is
+	// reset after it fired but before its callback reports that it stopped
+	var timer Timer
+	base.AfterFunc(time.Second, func() {
+		assert.False(t, timer.Reset(time.Second))
+		assert.Equal(t, 2, sink.liveTimers())
+	})
+	timer = clock.AfterFunc(time.Second, func() {})
+	assert.Equal(t, 1, sink.liveTimers())
+
+	base.Advance(time.Second)
+	assert.Equal(t, 1, sink.liveTimers())
+
+	base.Advance(time.Second)
+	assert.Equal(t, 0, sink.liveTimers())
+	assert.Equal(t, 2, sink.timersStarted())
+}
+
+func TestInstrumentedClockSleep(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0))
+	sink := &testMetricsSink{}
+	clock := NewInstrumentedClock(base, sink)
+
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Second)
+		close(done)
+	}()
+
+	// Overshoot the requested duration
+	base.BlockingAdvance(1500 * time.Millisecond)
+	eventually(t, chanClosed(done))
+	assert.Equal(t, [][2]time.Duration{{time.Second, 1500 * time.Millisecond}}, sink.sleepDurations())
+}
+
+func TestInstrumentedClockTicker(t *testing.T) {
+	t.Parallel()
+
+	base := NewMockClockAt(time.Unix(0, 0))
+	sink := &testMetricsSink{}
+	clock := NewInstrumentedClock(base, sink)
+
+	ticker := clock.NewTicker(time.Second)
+	assert.Equal(t, 1, sink.liveTickers())
+
+	// The tick is received after the clock has moved on
+	base.Advance(time.Second)
+	base.Advance(500 * time.Millisecond)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+	eventually(t, func() bool { return len(sink.tickLags()) == 1 })
+	assert.Equal(t, []time.Duration{500 * time.Millisecond}, sink.tickLags())
+
+	ticker.Stop()
+	ticker.Stop()
+	assert.Equal(t, 0, sink.liveTickers())
+}
+
+type testMetricsSink struct {
+	mu      sync.Mutex
+	timers  int
+	started int
+	tickers int
+	sleeps  [][2]time.Duration
+	tickLag []time.Duration
+}
+
+func (s *testMetricsSink) TimerStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timers++
+	s.started++
+}
+
+func (s *testMetricsSink) TimerStopped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timers--
+}
+
+func (s *testMetricsSink) TickerStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickers++
+}
+
+func (s *testMetricsSink) TickerStopped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickers--
+}
+
+func (s *testMetricsSink) SleepObserved(requested, actual time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sleeps = append(s.sleeps, [2]time.Duration{requested, actual})
+}
+
+func (s *testMetricsSink) TickObserved(lag time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickLag = append(s.tickLag, lag)
+}
+
+func (s *testMetricsSink) liveTimers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timers
+}
+
+func (s *testMetricsSink) timersStarted() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+func (s *testMetricsSink) liveTickers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tickers
+}
+
+func (s *testMetricsSink) sleepDurations() [][2]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sleeps
+}
+
+func (s *testMetricsSink) tickLags() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]time.Duration(nil), s.tickLag...)
+}
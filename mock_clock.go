@@ -1,15 +1,10 @@
 package glock
 
 import (
+	"math/rand"
 	"time"
 )
 
-var closedChan = make(chan time.Time)
-
-func init() {
-	close(closedChan)
-}
-
 // MockClock is an implementation of Clock that can be moved forward in time
 // in increments for testing code that relies on timeouts or other time-sensitive
 // constructs.
@@ -17,19 +12,65 @@ type MockClock struct {
 	*advanceable
 	afterArgs  []time.Duration
 	tickerArgs []time.Duration
+	timerArgs  []time.Duration
 }
 
 var _ Clock = &MockClock{}
 var _ Advanceable = &MockClock{}
 
+// MockClockOption configures a MockClock.
+type MockClockOption func(c *MockClock)
+
+// WithRandomTieOrder causes the clock to process pending events in deadline
+// order, shuffling events that share a deadline with the given random source.
+// By default, events are processed in the order they were registered. This order
+// determines when After channels receive and, with WithSynchronousAfterFunc and
+// WithSynchronousDelivery, the order in which AfterFunc callbacks run and timers
+// and tickers send.
+func WithRandomTieOrder(r *rand.Rand) MockClockOption {
+	return func(c *MockClock) { c.tieOrder = r }
+}
+
+// WithSynchronousAfterFunc causes the clock to run AfterFunc callbacks in the
+// goroutine that calls Advance, BlockingAdvance, or SetCurrent, so that every
+// callback that became due has returned by the time the call returns. Callbacks
+// are run in deadline order and without the clock's lock held, so they may call
+// back into the clock. As with time.AfterFunc, a callback is never run before
+// AfterFunc (or Reset) returns, so the caller may hold a lock that the callback
+// takes; a callback created (or reset) with a non-positive duration is run by the
+// next call to Advance, BlockingAdvance, SetCurrent, or WaitForCallbacks. By
+// default, each callback runs in its own goroutine, as with time.AfterFunc.
+func WithSynchronousAfterFunc() MockClockOption {
+	return func(c *MockClock) { c.syncCallbacks = true }
+}
+
+// WithSynchronousDelivery causes timers and tickers created by NewTimer,
+// NewTicker, NewTickerImmediate, and NewJitteredTicker to send on their channels
+// from the goroutine that calls Advance, BlockingAdvance, or SetCurrent, rather
+// than from their own goroutines. Values (and, with WithSynchronousAfterFunc,
+// callbacks) that become due are delivered one at a time in deadline order, each
+// send waiting until the value is received or its timer or ticker is stopped or
+// reset, so that readers receive them in a reproducible order. The call returns
+// once every value has been delivered, so readers must run in other goroutines.
+// Values due as a timer or ticker is created or reset are sent from their own
+// goroutine. Policy tickers buffer their ticks and are not affected.
+func WithSynchronousDelivery() MockClockOption {
+	return func(c *MockClock) { c.syncSends = true }
+}
+
 // NewMockClock creates a new MockClock with the internal time set to time.Now().
-func NewMockClock() *MockClock {
-	return NewMockClockAt(time.Now())
+func NewMockClock(opts ...MockClockOption) *MockClock {
+	return NewMockClockAt(time.Now(), opts...)
 }
 
 // NewMockClockAt creates a new MockClick with the internal time set to the given time.
-func NewMockClockAt(now time.Time) *MockClock {
-	return &MockClock{advanceable: newAdvanceableAt(now)}
+func NewMockClockAt(now time.Time, opts ...MockClockOption) *MockClock {
+	c := &MockClock{advanceable: newAdvanceableAt(now)}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Now returns the clock's internal time.
@@ -41,20 +82,23 @@ func (c *MockClock) Now() time.Time {
 }
 
 // After returns a channel that will be sent the clock's internal time once the
-// clock's internal time is at or past the supplied duration.
+// clock's internal time is at or past the supplied duration. As with time.After,
+// a non-positive duration sends the current time immediately.
 func (c *MockClock) After(duration time.Duration) <-chan time.Time {
 	c.m.Lock()
-	defer c.m.Unlock()
+	defer c.unlockAndDispatch()
 
 	c.afterArgs = append(c.afterArgs, duration)
 
+	ch := make(chan time.Time, 1)
+	subscriber := &afterSubscriber{ch: ch, deadline: c.now.Add(duration)}
 	if duration <= 0 {
-		return closedChan
+		c.announce(subscriber)
+		ch <- c.now
+		return ch
 	}
 
-	ch := make(chan time.Time, 1)
-	deadline := c.now.Add(duration)
-	c.register(&afterSubscriber{ch: ch, deadline: deadline})
+	c.register(subscriber)
 	return ch
 }
 
@@ -66,6 +110,16 @@ func (c *MockClock) BlockedOnAfter() int {
 	return len(c.subscribers)
 }
 
+// NextDeadline returns the earliest time at which a pending After channel,
+// timer, or ticker created by this clock will fire. If there are no pending
+// events, it returns false.
+func (c *MockClock) NextDeadline() (time.Time, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.nextDeadline()
+}
+
 // Sleep will block until the clock's internal time is at or past the given duration.
 func (c *MockClock) Sleep(duration time.Duration) {
 	<-c.After(duration)
@@ -85,13 +139,32 @@ func (c *MockClock) Until(t time.Time) time.Duration {
 // with a reference to a new channel returned by the After method.
 func (c *MockClock) BlockingAdvance(duration time.Duration) {
 	c.m.Lock()
-	defer c.m.Unlock()
 
 	for len(c.subscribers) == 0 {
 		c.cond.Wait()
 	}
 
 	c.setCurrent(c.now.Add(duration))
+	c.unlockAndDispatch()
+}
+
+// WaitForCallbacks blocks until every AfterFunc callback of this clock that has
+// fired has returned. Callbacks fire when the clock is moved at or past their
+// deadline, so this method can be called after Advance to wait for the effects
+// of the callbacks it triggered. With WithSynchronousAfterFunc, it first runs the
+// callbacks deferred by AfterFunc or Reset with a non-positive duration.
+func (c *MockClock) WaitForCallbacks() {
+	c.m.Lock()
+	for len(c.deferred) > 0 {
+		c.due, c.deferred = append(c.due, c.deferred...), nil
+		c.unlockAndDispatch()
+		c.m.Lock()
+	}
+
+	for c.inFlight > 0 {
+		c.idle.Wait()
+	}
+	c.m.Unlock()
 }
 
 // GetAfterArgs returns the duration of each call to After in the
@@ -101,8 +174,8 @@ func (c *MockClock) GetAfterArgs() []time.Duration {
 	c.m.Lock()
 	defer c.m.Unlock()
 
-	args := c.afterArgs
-	c.afterArgs = c.afterArgs[:0]
+	args := append([]time.Duration(nil), c.afterArgs...)
+	c.afterArgs = nil
 	return args
 }
 
@@ -113,12 +186,25 @@ func (c *MockClock) GetTickerArgs() []time.Duration {
 	c.m.Lock()
 	defer c.m.Unlock()
 
-	args := c.tickerArgs
-	c.tickerArgs = c.tickerArgs[:0]
+	args := append([]time.Duration(nil), c.tickerArgs...)
+	c.tickerArgs = nil
+	return args
+}
+
+// GetTimerArgs returns the duration of each call to create a new
+// timer (via NewTimer or AfterFunc) in the same order as they were
+// called. The list is cleared each time GetTimerArgs is called.
+func (c *MockClock) GetTimerArgs() []time.Duration {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	args := append([]time.Duration(nil), c.timerArgs...)
+	c.timerArgs = nil
 	return args
 }
 
 type afterSubscriber struct {
+	eventID
 	ch       chan time.Time
 	deadline time.Time
 }
@@ -134,3 +220,30 @@ func (s *afterSubscriber) signal(now time.Time) (requeue bool) {
 	s.ch <- s.deadline // inform user
 	return false       // unsubscribe
 }
+
+// next conforms to the subscriber interface.
+func (s *afterSubscriber) next() (time.Time, bool) {
+	return s.deadline, true
+}
+
+// kind conforms to the subscriber interface.
+func (s *afterSubscriber) kind() EventKind {
+	return AfterEvent
+}
+
+// save conforms to the restorable interface.
+func (s *afterSubscriber) save() func() bool {
+	return func() bool {
+		// Discard a value sent since the snapshot, as the deadline has
+		// not been reached yet
+		select {
+		case <-s.ch:
+		default:
+		}
+
+		return true
+	}
+}
+
+// cancel conforms to the restorable interface. The channel never receives.
+func (s *afterSubscriber) cancel() {}
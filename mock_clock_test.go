@@ -1,6 +1,8 @@
 package glock
 
 import (
+	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -118,6 +120,9 @@ func TestGetAfterArgs(t *testing.T) {
 	args = clock.GetAfterArgs()
 	assert.Len(t, args, 3)
 	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second, 6 * time.Second}, args)
+
+	clock.After(7 * time.Second)
+	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second, 6 * time.Second}, args)
 }
 
 func TestAfter(t *testing.T) {
@@ -143,6 +148,18 @@ func TestAfter(t *testing.T) {
 	consistently(t, chanDoesNotReceive(after))
 }
 
+func TestAfterNonPositiveDuration(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(10, 0))
+
+	for _, duration := range []time.Duration{0, -time.Second} {
+		after := clock.After(duration)
+		eventually(t, chanReceives(after, time.Unix(10, 0)))
+		consistently(t, chanDoesNotReceive(after))
+	}
+}
+
 func TestMultipleAfter(t *testing.T) {
 	t.Parallel()
 
@@ -272,3 +289,74 @@ func TestUntil(t *testing.T) {
 	assert.Equal(t, -5*time.Second, clock.Until(time.Unix(5, 0)))
 	assert.Equal(t, 5*time.Second, clock.Until(time.Unix(15, 0)))
 }
+
+func TestNextDeadline(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	_, ok := clock.NextDeadline()
+	assert.False(t, ok)
+
+	clock.After(3 * time.Second)
+	timer := clock.NewTimer(2 * time.Second)
+	ticker := clock.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	deadline, ok := clock.NextDeadline()
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(2, 0), deadline)
+
+	timer.Stop()
+	deadline, _ = clock.NextDeadline()
+	assert.Equal(t, time.Unix(3, 0), deadline)
+
+	clock.Advance(3 * time.Second)
+	deadline, _ = clock.NextDeadline()
+	assert.Equal(t, time.Unix(5, 0), deadline)
+}
+
+func TestRandomTieOrder(t *testing.T) {
+	t.Parallel()
+
+	orders := map[string]struct{}{}
+	for seed := int64(0); seed < 20; seed++ {
+		clock := NewMockClockAt(time.Unix(0, 0), WithRandomTieOrder(rand.New(rand.NewSource(seed))))
+
+		var order []string
+		clock.m.Lock()
+		clock.register(&orderSubscriber{deadline: time.Unix(2, 0), name: "c", order: &order})
+		clock.register(&orderSubscriber{deadline: time.Unix(1, 0), name: "a", order: &order})
+		clock.register(&orderSubscriber{deadline: time.Unix(1, 0), name: "b", order: &order})
+		clock.m.Unlock()
+
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, "c", order[2], "expected later deadline to be processed last")
+		orders[strings.Join(order, "")] = struct{}{}
+	}
+
+	assert.Equal(t, map[string]struct{}{"abc": {}, "bac": {}}, orders)
+}
+
+type orderSubscriber struct {
+	eventID
+	deadline time.Time
+	name     string
+	order    *[]string
+}
+
+func (s *orderSubscriber) signal(now time.Time) bool {
+	if now.Before(s.deadline) {
+		return true
+	}
+
+	*s.order = append(*s.order, s.name)
+	return false
+}
+
+func (s *orderSubscriber) next() (time.Time, bool) {
+	return s.deadline, true
+}
+
+func (s *orderSubscriber) kind() EventKind {
+	return AfterEvent
+}
@@ -1,6 +1,7 @@
 package glock
 
 import (
+	"math/rand"
 	"time"
 )
 
@@ -9,10 +10,16 @@ import (
 // constructs.
 type MockTicker struct {
 	*advanceable
+	eventID
 	duration time.Duration
+	interval func() time.Duration
 	deadline time.Time
 	ch       chan time.Time
 	stopped  bool
+
+	// abandon is closed to abandon the pending sends of the ticker's ticks
+	// once the ticker is stopped.
+	abandon chan struct{}
 }
 
 var _ Ticker = &MockTicker{}
@@ -20,16 +27,42 @@ var _ Advanceable = &MockTicker{}
 
 // NewTicker creates a new Ticker tied to the internal MockClock time that ticks
 // at intervals similar to time.NewTicker().  It will also skip or drop ticks
-// for slow readers similar to time.NewTicker() as well.
+// for slow readers similar to time.NewTicker() as well. Like time.NewTicker(),
+// this method panics if the duration is not positive.
 func (c *MockClock) NewTicker(duration time.Duration) Ticker {
 	c.m.Lock()
-	defer c.m.Unlock()
+	defer c.unlockAndDispatch()
 
 	c.tickerArgs = append(c.tickerArgs, duration)
 
 	return newMockTickerAt(c.advanceable, duration)
 }
 
+// NewTickerImmediate creates a new Ticker tied to the internal MockClock time
+// that ticks once at the current time and then at the given interval.
+func (c *MockClock) NewTickerImmediate(duration time.Duration) Ticker {
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.tickerArgs = append(c.tickerArgs, duration)
+
+	return newVariantMockTicker(c.advanceable, duration, nil, c.now)
+}
+
+// NewJitteredTicker creates a new Ticker tied to the internal MockClock time
+// whose intervals are drawn uniformly from the given duration plus or minus the
+// given fraction of it. Given a seeded random source, the sequence of deadlines
+// (see NextDeadline) is reproducible.
+func (c *MockClock) NewJitteredTicker(duration time.Duration, jitter float64, r *rand.Rand) Ticker {
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.tickerArgs = append(c.tickerArgs, duration)
+
+	interval := jitteredInterval(duration, jitter, r)
+	return newVariantMockTicker(c.advanceable, duration, interval, c.now.Add(interval()))
+}
+
 // NewMockTicker creates a new MockTicker with the internal time set to time.Now().
 func NewMockTicker(duration time.Duration) *MockTicker {
 	return NewMockTickerAt(time.Now(), duration)
@@ -41,19 +74,35 @@ func NewMockTickerAt(now time.Time, duration time.Duration) *MockTicker {
 }
 
 func newMockTickerAt(advanceable *advanceable, duration time.Duration) *MockTicker {
-	if duration == 0 {
-		panic("duration cannot be 0")
-	}
+	return newVariantMockTicker(advanceable, duration, nil, advanceable.now.Add(duration))
+}
+
+// newVariantMockTicker creates a ticker whose first tick is due at the given
+// deadline and whose later intervals are drawn from the given function (or
+// fixed, if nil).
+func newVariantMockTicker(advanceable *advanceable, duration time.Duration, interval func() time.Duration, deadline time.Time) *MockTicker {
+	checkTickerInterval(duration)
 
 	t := &MockTicker{
 		advanceable: advanceable,
 		duration:    duration,
-		deadline:    advanceable.now.Add(duration),
+		interval:    interval,
+		deadline:    deadline,
 		ch:          make(chan time.Time),
+		abandon:     make(chan struct{}),
 	}
 
-	go t.process()
 	advanceable.register(t)
+	if advanceable.syncSends {
+		if !t.now.Before(t.deadline) {
+			// The caller cannot receive the tick before the call returns
+			go sendOrCancel(t.ch, t.deadline, t.abandon)
+			t.skipElapsed()
+		}
+	} else {
+		go t.process()
+	}
+
 	return t
 }
 
@@ -69,6 +118,7 @@ func (t *MockTicker) Stop() {
 	defer t.cond.L.Unlock()
 
 	t.stopped = true
+	t.cancelSends()
 	t.cond.Broadcast()
 }
 
@@ -84,28 +134,109 @@ func (t *MockTicker) BlockingAdvance(duration time.Duration) {
 
 	if !t.now.Before(t.deadline) {
 		t.ch <- t.deadline
-		t.deadline = t.deadline.Add(t.duration)
+		t.deadline = t.deadline.Add(t.nextInterval())
 	}
 }
 
+// process sends the ticker's ticks until it is stopped. Each tick is sent
+// without holding the lock, so that a tick that is not read does not block the
+// clock. The earliest tick that elapses while a send is pending is sent once it
+// completes, and the later ones are dropped.
 func (t *MockTicker) process() {
 	t.cond.L.Lock()
 	defer t.cond.L.Unlock()
 
-	for !t.stopped {
+	abandon := t.abandon
+	for !t.stopped && t.abandon == abandon {
 		if !t.now.Before(t.deadline) {
-			t.ch <- t.deadline
+			tick := t.deadline
+			t.skipElapsed()
 
-			for !t.now.Before(t.deadline) {
-				t.deadline = t.deadline.Add(t.duration)
+			t.cond.L.Unlock()
+			select {
+			case t.ch <- tick:
+			case <-abandon:
 			}
+			t.cond.L.Lock()
+
+			continue
 		}
 
 		t.cond.Wait()
 	}
 }
 
+// skipElapsed moves the deadline past the current time, dropping the ticks
+// that elapsed before it. This method assumes the lock is held.
+func (t *MockTicker) skipElapsed() {
+	for !t.now.Before(t.deadline) {
+		t.deadline = t.deadline.Add(t.nextInterval())
+	}
+}
+
+// cancelSends abandons the pending sends of ticks that have not yet been
+// received. This method assumes the lock is held.
+func (t *MockTicker) cancelSends() {
+	if t.abandon != nil {
+		close(t.abandon)
+		t.abandon = nil
+	}
+}
+
+// nextInterval returns the duration between the current and the next tick.
+func (t *MockTicker) nextInterval() time.Duration {
+	if t.interval == nil {
+		return t.duration
+	}
+
+	return t.interval()
+}
+
 // signal conforms to the subscriber interface.
 func (t *MockTicker) signal(now time.Time) (requeue bool) {
+	if t.syncSends && !t.stopped && !now.Before(t.deadline) {
+		t.queueSend(t.deadline, t.ch, t.deadline, t.abandon)
+
+		for !now.Before(t.deadline) {
+			t.deadline = t.deadline.Add(t.nextInterval())
+		}
+	}
+
 	return !t.stopped
 }
+
+// next conforms to the subscriber interface.
+func (t *MockTicker) next() (time.Time, bool) {
+	return t.deadline, !t.stopped
+}
+
+// kind conforms to the subscriber interface.
+func (t *MockTicker) kind() EventKind {
+	return TickerEvent
+}
+
+// save conforms to the restorable interface.
+func (t *MockTicker) save() func() bool {
+	deadline, stopped := t.deadline, t.stopped
+
+	return func() bool {
+		t.cancelSends()
+		if !stopped {
+			t.abandon = make(chan struct{})
+			if !t.syncSends {
+				go t.process()
+			}
+		}
+
+		t.deadline, t.stopped = deadline, stopped
+		t.cond.Broadcast()
+		return !stopped
+	}
+}
+
+// cancel conforms to the restorable interface.
+func (t *MockTicker) cancel() {
+	t.cancelSends()
+	t.stopped = true
+	t.cond.Broadcast()
+}
@@ -27,13 +27,23 @@ func TestGetTickerArgs(t *testing.T) {
 	args = clock.GetTickerArgs()
 	assert.Len(t, args, 3)
 	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second, 6 * time.Second}, args)
+
+	clock.NewTicker(7 * time.Second)
+	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second, 6 * time.Second}, args)
 }
 
-func TestNewTickerNoDuration(t *testing.T) {
+func TestNewTickerNonPositiveDuration(t *testing.T) {
 	t.Parallel()
 
+	const message = "non-positive interval for NewTicker"
 	clock := NewMockClock()
-	assert.Panics(t, func() { clock.NewTicker(0) })
+
+	assert.PanicsWithValue(t, message, func() { clock.NewTicker(0) })
+	assert.PanicsWithValue(t, message, func() { clock.NewTicker(-time.Second) })
+	assert.PanicsWithValue(t, message, func() { NewMockTicker(0) })
+	assert.PanicsWithValue(t, message, func() { NewMockTickerAt(time.Unix(0, 0), -time.Second) })
+	assert.PanicsWithValue(t, message, func() { NewRealClock().NewTicker(0) })
+	assert.PanicsWithValue(t, message, func() { NewRealClock().NewTicker(-time.Second) })
 }
 
 func TestTickerOnTime(t *testing.T) {
@@ -249,3 +259,32 @@ func TestTickerStopped(t *testing.T) {
 	clock.Advance(2 * time.Second)
 	consistently(t, chanDoesNotReceive(ticker.Chan()))
 }
+
+func TestTickerUnreadDoesNotBlockClock(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewTickerImmediate(time.Second)
+
+	advanced := make(chan struct{})
+	go func() {
+		defer close(advanced)
+
+		for i := 0; i < 10; i++ {
+			time.Sleep(time.Millisecond)
+			clock.Advance(time.Second)
+		}
+	}()
+	eventually(t, structChanReceives(advanced))
+
+	// Only the earliest tick that elapsed while a tick was pending is kept
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(0, 0)))
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+	consistently(t, chanDoesNotReceive(ticker.Chan()))
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(11, 0)))
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	consistently(t, chanDoesNotReceive(ticker.Chan()))
+}
@@ -13,27 +13,63 @@ func sendTime(t *MockTimer) {
 // constructs.
 type MockTimer struct {
 	*advanceable
+	eventID
 	deadline time.Time
 	ch       chan time.Time
 	stopped  bool
 	f        func(*MockTimer)
+
+	// callback is set for timers created by AfterFunc. These timers fire
+	// when signaled by the clock instead of from a process goroutine.
+	callback bool
+
+	// abandon is closed to abandon a pending send of the timer's value once
+	// the timer is stopped or reset.
+	abandon chan struct{}
 }
 
 var _ Timer = &MockTimer{}
 var _ Advanceable = &MockTimer{}
 
 // NewTimer creates a new Timer tied to the internal MockClock time that functions
-// similar to time.NewTimer().
+// similar to time.NewTimer(). A timer with a non-positive duration fires immediately.
 func (c *MockClock) NewTimer(duration time.Duration) Timer {
-	return newMockTimerAt(c.advanceable, duration, sendTime)
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.timerArgs = append(c.timerArgs, duration)
+
+	return newMockTimerAt(c.advanceable, c.now.Add(duration), sendTime)
 }
 
 // AfterFunc creates a new Timer tied to the internal MockClock time that functions
-// similar to time.AfterFunc().
+// similar to time.AfterFunc(). A non-positive duration calls f immediately. See
+// WithSynchronousAfterFunc for running f in the goroutine that advances the clock
+// (in which case f is never called before AfterFunc returns).
 func (c *MockClock) AfterFunc(duration time.Duration, f func()) Timer {
-	return newMockTimerAt(c.advanceable, duration, func(mt *MockTimer) {
-		go f()
-	})
+	c.m.Lock()
+	t := c.newAfterFunc(duration, c.now.Add(duration), f)
+	c.unlockAndDispatch()
+
+	return t
+}
+
+// newAfterFunc creates a timer that calls f at the given deadline, calling it
+// immediately if the deadline has passed. This method assumes the lock is held.
+func (c *MockClock) newAfterFunc(duration time.Duration, deadline time.Time, f func()) *MockTimer {
+	c.timerArgs = append(c.timerArgs, duration)
+
+	t := &MockTimer{
+		advanceable: c.advanceable,
+		deadline:    deadline,
+		ch:          make(chan time.Time),
+		f:           func(mt *MockTimer) { mt.fireCallback(mt.deadline, f) },
+		callback:    true,
+	}
+
+	c.register(t)
+	t.tryExecuteDeferred()
+	return t
 }
 
 // NewMockTimer creates a new MockTimer with the internal time set to time.Now().
@@ -43,27 +79,30 @@ func NewMockTimer(duration time.Duration) *MockTimer {
 
 // NewMockTimerAt creates a new MockTimer with the internal time set to the given time.
 func NewMockTimerAt(now time.Time, duration time.Duration) *MockTimer {
-	return newMockTimerAt(newAdvanceableAt(now), duration, sendTime)
+	return newMockTimerAt(newAdvanceableAt(now), now.Add(duration), sendTime)
 }
 
+// newMockTimerAt creates a timer that fires at the given deadline. A deadline
+// that has already passed (e.g. from a non-positive duration) fires the timer
+// immediately, as with time.NewTimer.
 func newMockTimerAt(
 	advanceable *advanceable,
-	duration time.Duration,
+	deadline time.Time,
 	f func(*MockTimer),
 ) *MockTimer {
-	if duration == 0 {
-		panic("duration cannot be 0")
-	}
-
 	t := &MockTimer{
 		advanceable: advanceable,
-		deadline:    advanceable.now.Add(duration),
+		deadline:    deadline,
 		ch:          make(chan time.Time),
 		f:           f,
 	}
 
-	go t.process()
 	advanceable.register(t)
+	if advanceable.syncSends {
+		t.sendIfDue()
+	} else {
+		go t.process()
+	}
 
 	return t
 }
@@ -79,19 +118,36 @@ func (t *MockTimer) Chan() <-chan time.Time {
 // was called it will return true.
 func (t *MockTimer) Reset(duration time.Duration) bool {
 	t.cond.L.Lock()
-	defer t.cond.L.Unlock()
+	return t.resetLocked(t.now.Add(duration))
+}
 
+// resetLocked resets the deadline of the timer to the given time. This method
+// assumes the lock is held and releases it.
+func (t *MockTimer) resetLocked(deadline time.Time) bool {
 	wasRunning := !t.stopped
 
-	t.deadline = t.now.Add(duration)
+	t.cancelSend()
+	t.deadline = deadline
 	t.stopped = false
 
 	if !wasRunning {
-		go t.process()
+		if !t.callback && !t.syncSends {
+			go t.process()
+		}
+
 		t.advanceable.register(t)
+	} else {
+		t.announce(t)
+	}
+
+	if t.callback {
+		t.tryExecuteDeferred()
+	} else if t.syncSends {
+		t.sendIfDue()
 	}
 
 	t.cond.Broadcast()
+	t.unlockAndDispatch()
 
 	return wasRunning
 }
@@ -101,6 +157,8 @@ func (t *MockTimer) Stop() bool {
 	t.cond.L.Lock()
 	defer t.cond.L.Unlock()
 
+	t.cancelSend()
+
 	if t.stopped {
 		return false
 	}
@@ -117,11 +175,9 @@ func (t *MockTimer) Stop() bool {
 // channel.
 func (t *MockTimer) BlockingAdvance(duration time.Duration) {
 	t.m.Lock()
-	defer t.m.Unlock()
-
 	t.now = t.now.Add(duration)
-
 	t.tryExecute()
+	t.unlockAndDispatch()
 }
 
 func (t *MockTimer) tryExecute() {
@@ -133,18 +189,108 @@ func (t *MockTimer) tryExecute() {
 	}
 }
 
+// tryExecuteDeferred fires an AfterFunc timer that is created or reset with a
+// deadline that has already passed. In synchronous mode, its callback is deferred
+// to the next advance of the clock or call to WaitForCallbacks.
+func (t *MockTimer) tryExecuteDeferred() {
+	t.deferring = true
+	t.tryExecute()
+	t.deferring = false
+}
+
+// sendIfDue fires a timer in synchronous delivery mode whose deadline has
+// already passed as it is created or reset. The value is sent from its own
+// goroutine, as the caller cannot receive it before the call returns. This
+// method assumes the lock is held.
+func (t *MockTimer) sendIfDue() {
+	if t.now.Before(t.deadline) {
+		return
+	}
+
+	t.stopped = true
+	t.abandon = make(chan struct{})
+	go sendOrCancel(t.ch, t.now, t.abandon)
+}
+
+// cancelSend abandons a pending send of the timer's value that has not yet been
+// received. This method assumes the lock is held.
+func (t *MockTimer) cancelSend() {
+	if t.abandon != nil {
+		close(t.abandon)
+		t.abandon = nil
+	}
+}
+
+// process waits for the timer to fire and then sends its value without holding
+// the lock, so that a value that is never received does not block the clock.
 func (t *MockTimer) process() {
 	t.cond.L.Lock()
 	defer t.cond.L.Unlock()
 
 	for !t.stopped {
-		t.tryExecute()
+		if !t.now.Before(t.deadline) {
+			t.stopped = true
+			t.cond.Broadcast()
+
+			t.abandon = make(chan struct{})
+			go sendOrCancel(t.ch, t.now, t.abandon)
+			return
+		}
+
 		t.cond.Wait()
 	}
-
 }
 
 // signal conforms to the subscriber interface.
 func (t *MockTimer) signal(now time.Time) bool {
+	if t.callback && !t.stopped && !now.Before(t.deadline) {
+		t.stopped = true
+		t.f(t)
+	}
+
+	if !t.callback && t.syncSends && !t.stopped && !now.Before(t.deadline) {
+		t.stopped = true
+		t.abandon = make(chan struct{})
+		t.queueSend(t.deadline, t.ch, now, t.abandon)
+	}
+
 	return !t.stopped
 }
+
+// next conforms to the subscriber interface.
+func (t *MockTimer) next() (time.Time, bool) {
+	return t.deadline, !t.stopped
+}
+
+// kind conforms to the subscriber interface.
+func (t *MockTimer) kind() EventKind {
+	if t.callback {
+		return AfterFuncEvent
+	}
+
+	return TimerEvent
+}
+
+// save conforms to the restorable interface.
+func (t *MockTimer) save() func() bool {
+	deadline, stopped := t.deadline, t.stopped
+
+	return func() bool {
+		t.cancelSend()
+
+		if t.stopped && !stopped && !t.callback && !t.syncSends {
+			go t.process()
+		}
+
+		t.deadline, t.stopped = deadline, stopped
+		t.cond.Broadcast()
+		return !stopped
+	}
+}
+
+// cancel conforms to the restorable interface.
+func (t *MockTimer) cancel() {
+	t.cancelSend()
+	t.stopped = true
+	t.cond.Broadcast()
+}
@@ -1,6 +1,7 @@
 package glock
 
 import (
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -8,15 +9,66 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGetTimerArgs(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClock()
+
+	clock.NewTimer(3 * time.Second)
+	clock.AfterFunc(1*time.Second, func() {})
+	clock.NewTimer(2 * time.Second)
+
+	args := clock.GetTimerArgs()
+	assert.Len(t, args, 3)
+	assert.Equal(t, []time.Duration{3 * time.Second, 1 * time.Second, 2 * time.Second}, args)
+
+	clock.NewTimer(4 * time.Second)
+	clock.NewTimer(5 * time.Second)
+
+	args = clock.GetTimerArgs()
+	assert.Len(t, args, 2)
+	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second}, args)
+
+	clock.NewTimer(6 * time.Second)
+	assert.Equal(t, []time.Duration{4 * time.Second, 5 * time.Second}, args)
+}
+
 func TestMockTimer(t *testing.T) {
 	t.Parallel()
 
 	t.Run("new timer", func(t *testing.T) {
-		t.Run("no duration", func(t *testing.T) {
-			clock := NewMockClock()
-			assert.Panics(t, func() { clock.NewTimer(0) })
+		t.Run("no duration fires immediately", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			timer := clock.NewTimer(0)
+			eventually(t, chanReceives(timer.Chan(), time.Unix(0, 0)))
+			assert.False(t, timer.Stop())
+		})
+		t.Run("negative duration fires immediately", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			timer := clock.NewTimer(-time.Second)
+			eventually(t, chanReceives(timer.Chan(), time.Unix(0, 0)))
+		})
+		t.Run("non-positive reset fires immediately", func(t *testing.T) {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			timer := clock.NewTimer(time.Second)
+			assert.True(t, timer.Reset(0))
+			eventually(t, chanReceives(timer.Chan(), time.Unix(0, 0)))
+		})
+		t.Run("standalone mock timer", func(t *testing.T) {
+			timer := NewMockTimerAt(time.Unix(0, 0), 0)
+			eventually(t, chanReceives(timer.Chan(), time.Unix(0, 0)))
 		})
 	})
+	t.Run("afterfunc with non-positive duration", func(t *testing.T) {
+		called := make(chan struct{})
+		clock := NewMockClockAt(time.Unix(0, 0))
+		clock.AfterFunc(-time.Second, func() { close(called) })
+		eventually(t, structChanReceives(called))
+
+		realCalled := make(chan struct{})
+		NewRealClock().AfterFunc(0, func() { close(realCalled) })
+		eventually(t, structChanReceives(realCalled))
+	})
 	t.Run("blocking advance", func(t *testing.T) {
 		t.Run("blocks until channel is read", func(t *testing.T) {
 			clock := NewMockClockAt(time.Unix(0, 0))
@@ -148,6 +200,157 @@ func TestMockTimer(t *testing.T) {
 			clock.Advance(500 * time.Millisecond)
 			eventually(t, func() bool { return atomic.LoadUint32(&funcCalled) == 1 })
 		})
+		t.Run("waits for callbacks", func(t *testing.T) {
+			funcCalled := uint32(0)
+			release := make(chan struct{})
+
+			clock := NewMockClockAt(time.Unix(0, 0))
+			clock.AfterFunc(1*time.Second, func() {
+				<-release
+				atomic.AddUint32(&funcCalled, 1)
+			})
+
+			// Nothing has fired yet
+			clock.WaitForCallbacks()
+			clock.Advance(1 * time.Second)
+
+			finishedWaiting := make(chan struct{})
+			go func() {
+				clock.WaitForCallbacks()
+				close(finishedWaiting)
+			}()
+			consistently(t, structChanDoesNotReceive(finishedWaiting))
+
+			close(release)
+			eventually(t, chanClosed(finishedWaiting))
+			assert.Equal(t, uint32(1), atomic.LoadUint32(&funcCalled))
+		})
+	})
+	t.Run("synchronous afterfunc", func(t *testing.T) {
+		t.Run("runs before advance returns", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			clock.AfterFunc(1*time.Second, func() { calls = append(calls, 1) })
+
+			clock.Advance(500 * time.Millisecond)
+			assert.Empty(t, calls)
+
+			clock.Advance(500 * time.Millisecond)
+			assert.Equal(t, []int{1}, calls)
+
+			clock.Advance(1 * time.Second)
+			assert.Equal(t, []int{1}, calls)
+		})
+		t.Run("runs in deadline order", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			clock.AfterFunc(3*time.Second, func() { calls = append(calls, 3) })
+			clock.AfterFunc(1*time.Second, func() { calls = append(calls, 1) })
+			clock.AfterFunc(2*time.Second, func() { calls = append(calls, 2) })
+
+			clock.SetCurrent(time.Unix(5, 0))
+			assert.Equal(t, []int{1, 2, 3}, calls)
+		})
+		t.Run("callbacks can call into the clock", func(t *testing.T) {
+			var calls []time.Time
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			clock.AfterFunc(1*time.Second, func() {
+				calls = append(calls, clock.Now())
+
+				clock.AfterFunc(0, func() { calls = append(calls, clock.Now()) })
+				clock.AfterFunc(1*time.Second, func() { calls = append(calls, clock.Now()) })
+			})
+
+			clock.Advance(1 * time.Second)
+			assert.Equal(t, []time.Time{time.Unix(1, 0)}, calls)
+
+			// The callback created with a zero duration is deferred to the next advance
+			clock.Advance(1 * time.Second)
+			assert.Equal(t, []time.Time{time.Unix(1, 0), time.Unix(2, 0), time.Unix(2, 0)}, calls)
+		})
+		t.Run("defers non-positive durations", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			timer := clock.AfterFunc(0, func() { calls = append(calls, len(calls)) })
+			assert.Empty(t, calls)
+
+			clock.Advance(0)
+			assert.Equal(t, []int{0}, calls)
+
+			assert.False(t, timer.Reset(-time.Second))
+			assert.Equal(t, []int{0}, calls)
+
+			clock.WaitForCallbacks()
+			assert.Equal(t, []int{0, 1}, calls)
+		})
+		t.Run("does not run while the caller holds a lock", func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				calls int
+			)
+
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			callback := func() {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls++
+			}
+
+			// Arming a timer while holding a lock that its callback takes is
+			// safe with time.AfterFunc, so must be safe here as well
+			mu.Lock()
+			timer := clock.AfterFunc(0, callback)
+			timer.Reset(0)
+			mu.Unlock()
+
+			clock.WaitForCallbacks()
+			assert.Equal(t, 2, calls)
+		})
+		t.Run("does not run when stopped", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			timer := clock.AfterFunc(1*time.Second, func() { calls = append(calls, 1) })
+
+			assert.True(t, timer.Stop())
+			clock.Advance(1 * time.Second)
+			assert.Empty(t, calls)
+
+			assert.False(t, timer.Reset(1*time.Second))
+			clock.Advance(1 * time.Second)
+			assert.Equal(t, []int{1}, calls)
+		})
+		t.Run("runs callbacks queued by the calling goroutine", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			clock.AfterFunc(1*time.Second, func() {
+				calls = append(calls, 1)
+
+				// A call made by another goroutine while the advancing goroutine
+				// is still running callbacks must run its own callbacks before it
+				// returns, and must not run the ones queued by the advance
+				seen := make(chan []int)
+				go func() {
+					clock.AfterFunc(0, func() { calls = append(calls, 3) })
+					clock.WaitForCallbacks()
+					seen <- append([]int(nil), calls...)
+				}()
+
+				assert.Equal(t, []int{1, 3}, <-seen)
+			})
+			clock.AfterFunc(2*time.Second, func() { calls = append(calls, 2) })
+
+			clock.Advance(3 * time.Second)
+			assert.Equal(t, []int{1, 3, 2}, calls)
+		})
+		t.Run("runs on blocking advance", func(t *testing.T) {
+			var calls []int
+			clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+			clock.AfterFunc(1*time.Second, func() { calls = append(calls, 1) })
+
+			clock.BlockingAdvance(1 * time.Second)
+			assert.Equal(t, []int{1}, calls)
+		})
 	})
 	t.Run("firing", func(t *testing.T) {
 		t.Run("on time", func(t *testing.T) {
@@ -224,6 +427,27 @@ func TestMockTimer(t *testing.T) {
 			consistently(t, chanDoesNotReceive(timer.Chan()))
 		})
 	})
+	t.Run("unread value does not block the clock", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		timer := clock.NewTimer(time.Second)
+		clock.Advance(time.Second)
+
+		advanced := make(chan struct{})
+		go func() {
+			defer close(advanced)
+
+			for i := 0; i < 10; i++ {
+				time.Sleep(time.Millisecond)
+				clock.Advance(time.Second)
+			}
+		}()
+		eventually(t, structChanReceives(advanced))
+
+		assert.False(t, timer.Stop())
+		assert.False(t, timer.Reset(time.Second))
+		clock.Advance(time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(12, 0)))
+	})
 	t.Run("stopping", func(t *testing.T) {
 		t.Run("returns true when stopping timer", func(t *testing.T) {
 			clock := NewMockClock()
@@ -332,3 +556,67 @@ func TestMockTimer(t *testing.T) {
 		})
 	})
 }
+
+func TestSynchronousDelivery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers in deadline order before advance returns", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousDelivery())
+		late := clock.NewTimer(2 * time.Second)
+		early := clock.NewTimer(time.Second)
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		var order []string
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 3; i++ {
+				select {
+				case <-late.Chan():
+					order = append(order, "late")
+				case <-early.Chan():
+					order = append(order, "early")
+				case <-ticker.Chan():
+					order = append(order, "ticker")
+				}
+			}
+		}()
+
+		clock.Advance(2 * time.Second)
+		eventually(t, structChanReceives(done))
+		assert.Equal(t, []string{"early", "ticker", "late"}, order)
+	})
+	t.Run("stop abandons pending send", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousDelivery())
+		a := clock.NewTimer(time.Second)
+		b := clock.NewTimer(time.Second)
+
+		go func() {
+			select {
+			case <-a.Chan():
+			case <-b.Chan():
+			}
+
+			a.Stop()
+			b.Stop()
+		}()
+
+		advanced := make(chan struct{})
+		go func() {
+			clock.Advance(time.Second)
+			close(advanced)
+		}()
+		eventually(t, structChanReceives(advanced))
+	})
+	t.Run("immediate values are sent from their own goroutine", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousDelivery())
+		timer := clock.NewTimer(0)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(0, 0)))
+
+		ticker := clock.NewTickerImmediate(time.Second)
+		defer ticker.Stop()
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(0, 0)))
+	})
+}
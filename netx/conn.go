@@ -0,0 +1,185 @@
+// Package netx provides net.Conn implementations whose deadlines are enforced
+// by a glock.Clock, so that timeout handling can be tested with a mock clock.
+package netx
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// aLongTimeAgo is a non-zero time in the past, used to make the underlying
+// connection's pending and future operations fail immediately.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// conn is a net.Conn whose deadlines are tracked by a glock.Clock. When a
+// deadline passes on the clock, the wrapped connection's (real) deadline is
+// moved into the past to interrupt blocked operations.
+type conn struct {
+	net.Conn
+	read  *deadline
+	write *deadline
+}
+
+// WrapConn returns a connection that reads from and writes to the given
+// connection, but whose deadlines are measured by the given clock rather than
+// by the runtime. Operations that exceed a deadline fail with an error that
+// wraps os.ErrDeadlineExceeded.
+//
+// The wrapped connection must support deadlines. Its deadlines should not be
+// set directly once it has been wrapped.
+func WrapConn(c net.Conn, clock glock.Clock) net.Conn {
+	return &conn{
+		Conn:  c,
+		read:  newDeadline(clock, c.SetReadDeadline),
+		write: newDeadline(clock, c.SetWriteDeadline),
+	}
+}
+
+// Pipe is like net.Pipe, but the deadlines of both ends are measured by the
+// given clock.
+func Pipe(clock glock.Clock) (net.Conn, net.Conn) {
+	c1, c2 := net.Pipe()
+	return WrapConn(c1, clock), WrapConn(c2, clock)
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	return n, c.read.translate(err)
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	return n, c.write.translate(err)
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	if err := c.read.set(t); err != nil {
+		return err
+	}
+
+	return c.write.set(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	return c.read.set(t)
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	return c.write.set(t)
+}
+
+func (c *conn) Close() error {
+	c.read.stop()
+	c.write.stop()
+	return c.Conn.Close()
+}
+
+// deadline tracks a single read or write deadline on a glock.Clock.
+type deadline struct {
+	clock      glock.Clock
+	setReal    func(t time.Time) error
+	mu         sync.Mutex
+	timer      glock.Timer
+	cancel     chan struct{}
+	expired    bool
+	generation int
+}
+
+func newDeadline(clock glock.Clock, setReal func(t time.Time) error) *deadline {
+	return &deadline{clock: clock, setReal: setReal}
+}
+
+// set replaces the deadline. A zero value means operations will not time out.
+func (d *deadline) set(t time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopTimer()
+	d.expired = false
+
+	if t.IsZero() {
+		return d.setReal(time.Time{})
+	}
+
+	duration := d.clock.Until(t)
+	if duration <= 0 {
+		d.expired = true
+		return d.setReal(aLongTimeAgo)
+	}
+
+	// Clear a previous expiry
+	if err := d.setReal(time.Time{}); err != nil {
+		return err
+	}
+
+	generation := d.generation
+	timer := d.clock.NewTimer(duration)
+	cancel := make(chan struct{})
+	d.timer, d.cancel = timer, cancel
+
+	go func() {
+		select {
+		case <-timer.Chan():
+			d.expire(generation)
+		case <-cancel:
+		}
+	}()
+
+	return nil
+}
+
+// expire marks the deadline as passed and interrupts pending operations on the
+// wrapped connection, unless the deadline has changed since the timer started.
+func (d *deadline) expire(generation int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if generation != d.generation {
+		return
+	}
+
+	d.expired = true
+	_ = d.setReal(aLongTimeAgo)
+}
+
+// stop cancels the timer of the current deadline.
+func (d *deadline) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopTimer()
+}
+
+// stopTimer cancels the timer of the current deadline. This method assumes the
+// lock is held.
+func (d *deadline) stopTimer() {
+	d.generation++
+
+	if d.timer != nil {
+		d.timer.Stop()
+		close(d.cancel)
+		d.timer, d.cancel = nil, nil
+	}
+}
+
+// translate ensures that an error caused by an expired deadline wraps
+// os.ErrDeadlineExceeded, whatever the wrapped connection returned.
+func (d *deadline) translate(err error) error {
+	if err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.expired {
+		return os.ErrDeadlineExceeded
+	}
+
+	return err
+}
@@ -0,0 +1,178 @@
+package netx
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipe(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	go func() { _, _ = c1.Write([]byte("hello")) }()
+
+	buf := make([]byte, 5)
+	_, err := io.ReadFull(c2, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+}
+
+func TestReadDeadline(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	require.NoError(t, c1.SetReadDeadline(time.Unix(10, 0)))
+	errs := readAsync(c1)
+
+	clock.Advance(9 * time.Second)
+	assertBlocked(t, errs)
+
+	clock.Advance(time.Second)
+	assertDeadlineExceeded(t, errs)
+
+	// Subsequent reads fail until the deadline is changed
+	_, err := c1.Read(make([]byte, 1))
+	assert.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+}
+
+func TestReadDeadlineInPast(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(10, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	// Deadlines are measured by the clock, not by the runtime
+	require.NoError(t, c1.SetReadDeadline(time.Unix(5, 0)))
+	_, err := c1.Read(make([]byte, 1))
+	assert.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+}
+
+func TestReadDeadlineExtended(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	require.NoError(t, c1.SetReadDeadline(time.Unix(5, 0)))
+	clock.Advance(5 * time.Second)
+	assertDeadlineExceeded(t, readAsync(c1))
+
+	require.NoError(t, c1.SetReadDeadline(time.Unix(10, 0)))
+	errs := readAsync(c1)
+
+	clock.Advance(4 * time.Second)
+	assertBlocked(t, errs)
+
+	go func() { _, _ = c2.Write([]byte("x")) }()
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatalf("expected read to complete")
+	}
+}
+
+func TestClearDeadline(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	require.NoError(t, c1.SetReadDeadline(time.Unix(5, 0)))
+	require.NoError(t, c1.SetReadDeadline(time.Time{}))
+	errs := readAsync(c1)
+
+	clock.Advance(10 * time.Second)
+	assertBlocked(t, errs)
+}
+
+func TestWriteDeadline(t *testing.T) {
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c1, c2 := Pipe(clock)
+	defer c1.Close()
+	defer c2.Close()
+
+	require.NoError(t, c1.SetDeadline(time.Unix(10, 0)))
+
+	// Nothing reads from c2, so the write blocks
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c1.Write([]byte("hello"))
+		errs <- err
+	}()
+
+	clock.Advance(10 * time.Second)
+	assertDeadlineExceeded(t, errs)
+}
+
+func TestWrapConn(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %s", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		server, err := listener.Accept()
+		if err == nil {
+			defer server.Close()
+			_, _ = io.Copy(io.Discard, server)
+		}
+	}()
+
+	raw, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	clock := glock.NewMockClockAt(time.Unix(0, 0))
+	c := WrapConn(raw, clock)
+	defer c.Close()
+
+	require.NoError(t, c.SetReadDeadline(time.Unix(1, 0)))
+	errs := readAsync(c)
+
+	clock.Advance(time.Second)
+	assertDeadlineExceeded(t, errs)
+}
+
+func readAsync(c net.Conn) <-chan error {
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.Read(make([]byte, 1))
+		errs <- err
+	}()
+
+	return errs
+}
+
+func assertBlocked(t *testing.T, errs <-chan error) {
+	t.Helper()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("unexpected result %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func assertDeadlineExceeded(t *testing.T, errs <-chan error) {
+	t.Helper()
+
+	select {
+	case err := <-errs:
+		assert.True(t, errors.Is(err, os.ErrDeadlineExceeded), "expected deadline exceeded, got %v", err)
+	case <-time.After(time.Second):
+		t.Fatalf("expected operation to time out")
+	}
+}
@@ -0,0 +1,250 @@
+// Package ratelimit provides rate limiters whose notion of time is supplied
+// by a glock.Clock.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// Limit is the maximum rate of events, expressed as events per second.
+type Limit float64
+
+// Inf is an infinite rate limit; it allows all events.
+const Inf = Limit(math.MaxFloat64)
+
+// Every converts a minimum time interval between events to a Limit.
+func Every(interval time.Duration) Limit {
+	if interval <= 0 {
+		return Inf
+	}
+
+	return 1 / Limit(interval.Seconds())
+}
+
+// ErrExceedsBurst is returned from Wait when the number of requested events
+// can never be satisfied by the limiter's burst size.
+var ErrExceedsBurst = errors.New("ratelimit: requested events exceed burst")
+
+// Limiter is a token-bucket rate limiter. The bucket holds up to burst
+// tokens and is refilled at a rate of limit tokens per second. Time is read
+// from the clock supplied at construction, so a mock clock can be advanced
+// to refill the bucket in tests.
+type Limiter struct {
+	clock  glock.Clock
+	mu     sync.Mutex
+	limit  Limit
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a new Limiter that allows events up to the given rate
+// and permits bursts of at most burst events. The bucket starts full.
+func NewLimiter(clock glock.Clock, limit Limit, burst int) *Limiter {
+	return &Limiter{
+		clock:  clock,
+		limit:  limit,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Limit returns the limiter's current rate.
+func (l *Limiter) Limit() Limit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Burst returns the limiter's current burst size.
+func (l *Limiter) Burst() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.burst
+}
+
+// SetLimit changes the rate of the limiter. Tokens accumulated under the
+// previous rate are retained.
+func (l *Limiter) SetLimit(limit Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(l.clock.Now())
+	l.limit = limit
+}
+
+// SetBurst changes the burst size of the limiter. If the bucket currently
+// holds more tokens than the new burst size, the excess is discarded.
+func (l *Limiter) SetBurst(burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(l.clock.Now())
+	l.burst = burst
+	l.tokens = math.Min(l.tokens, float64(burst))
+}
+
+// Allow reports whether an event may happen now. If so, a token is consumed.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now. If so, n tokens are consumed.
+func (l *Limiter) AllowN(n int) bool {
+	return l.reserveN(n, 0).ok
+}
+
+// Reserve returns a Reservation that indicates how long the caller must wait
+// before an event may happen. The token is consumed immediately; call Cancel
+// on the reservation to return it if the event will not happen.
+func (l *Limiter) Reserve() *Reservation {
+	return l.ReserveN(1)
+}
+
+// ReserveN returns a Reservation that indicates how long the caller must wait
+// before n events may happen.
+func (l *Limiter) ReserveN(n int) *Reservation {
+	return l.reserveN(n, time.Duration(math.MaxInt64))
+}
+
+// Wait blocks until an event may happen or the context is canceled.
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n events may happen or the context is canceled. The
+// wait is performed on a timer created by the limiter's clock.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r := l.ReserveN(n)
+	if !r.ok {
+		return ErrExceedsBurst
+	}
+
+	delay := r.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := l.clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.Chan():
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// reserveN consumes n tokens if they will be available within maxWait.
+func (l *Limiter) reserveN(n int, maxWait time.Duration) *Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+
+	if l.limit == Inf {
+		return &Reservation{ok: true, limiter: l, timeToAct: now}
+	}
+
+	if n > l.burst {
+		return &Reservation{ok: false, limiter: l}
+	}
+
+	l.advance(now)
+
+	tokens := l.tokens - float64(n)
+	wait := time.Duration(0)
+	if tokens < 0 {
+		if l.limit <= 0 {
+			return &Reservation{ok: false, limiter: l}
+		}
+
+		wait = time.Duration(math.Ceil(-tokens / float64(l.limit) * float64(time.Second)))
+	}
+
+	if wait > maxWait {
+		return &Reservation{ok: false, limiter: l}
+	}
+
+	l.tokens = tokens
+
+	return &Reservation{
+		ok:        true,
+		limiter:   l,
+		tokens:    n,
+		timeToAct: now.Add(wait),
+	}
+}
+
+// advance refills the bucket with the tokens accumulated since the last
+// update. This method assumes the limiter's lock is held.
+func (l *Limiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*float64(l.limit))
+		l.last = now
+	}
+}
+
+// Reservation holds tokens reserved from a Limiter for events that are
+// permitted to happen at a future time.
+type Reservation struct {
+	ok        bool
+	limiter   *Limiter
+	tokens    int
+	timeToAct time.Time
+	canceled  bool
+}
+
+// OK returns whether the limiter can provide the requested number of tokens.
+// If OK is false, Delay returns an infinite duration and Cancel does nothing.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Delay returns the duration for which the reservation holder must wait
+// before the reserved events may happen.
+func (r *Reservation) Delay() time.Duration {
+	if !r.ok {
+		return time.Duration(math.MaxInt64)
+	}
+
+	if delay := r.limiter.clock.Until(r.timeToAct); delay > 0 {
+		return delay
+	}
+
+	return 0
+}
+
+// Cancel returns the reserved tokens to the limiter if the time to act has
+// not yet passed.
+func (r *Reservation) Cancel() {
+	if !r.ok || r.tokens == 0 {
+		return
+	}
+
+	l := r.limiter
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if r.canceled || !now.Before(r.timeToAct) {
+		return
+	}
+
+	r.canceled = true
+	l.advance(now)
+	l.tokens = math.Min(float64(l.burst), l.tokens+float64(r.tokens))
+}
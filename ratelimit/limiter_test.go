@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	assert.Equal(t, Limit(10), Every(100*time.Millisecond))
+	assert.Equal(t, Inf, Every(0))
+}
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("allow", func(t *testing.T) {
+		t.Run("consumes burst then refills", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 3)
+
+			assert.True(t, limiter.Allow())
+			assert.True(t, limiter.Allow())
+			assert.True(t, limiter.Allow())
+			assert.False(t, limiter.Allow())
+
+			clock.Advance(500 * time.Millisecond)
+			assert.False(t, limiter.Allow())
+
+			clock.Advance(500 * time.Millisecond)
+			assert.True(t, limiter.Allow())
+			assert.False(t, limiter.Allow())
+
+			clock.Advance(time.Hour)
+			assert.True(t, limiter.AllowN(3))
+			assert.False(t, limiter.Allow())
+		})
+		t.Run("infinite limit", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, Inf, 0)
+
+			for i := 0; i < 100; i++ {
+				assert.True(t, limiter.Allow())
+			}
+		})
+		t.Run("zero limit", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 0, 1)
+
+			assert.True(t, limiter.Allow())
+			clock.Advance(time.Hour)
+			assert.False(t, limiter.Allow())
+		})
+	})
+	t.Run("reserve", func(t *testing.T) {
+		t.Run("delays past burst", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 2, 1)
+
+			r1 := limiter.Reserve()
+			assert.True(t, r1.OK())
+			assert.Equal(t, time.Duration(0), r1.Delay())
+
+			r2 := limiter.Reserve()
+			assert.True(t, r2.OK())
+			assert.Equal(t, 500*time.Millisecond, r2.Delay())
+
+			r3 := limiter.Reserve()
+			assert.True(t, r3.OK())
+			assert.Equal(t, time.Second, r3.Delay())
+
+			clock.Advance(250 * time.Millisecond)
+			assert.Equal(t, 250*time.Millisecond, r2.Delay())
+			assert.Equal(t, 750*time.Millisecond, r3.Delay())
+		})
+		t.Run("exceeds burst", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 2)
+
+			assert.False(t, limiter.ReserveN(3).OK())
+		})
+		t.Run("cancel returns tokens", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 1)
+
+			assert.True(t, limiter.Allow())
+
+			r := limiter.Reserve()
+			assert.Equal(t, time.Second, r.Delay())
+			r.Cancel()
+			r.Cancel()
+
+			clock.Advance(time.Second)
+			assert.True(t, limiter.Allow())
+			assert.False(t, limiter.Allow())
+		})
+	})
+	t.Run("wait", func(t *testing.T) {
+		t.Run("blocks until tokens are available", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 1)
+
+			assert.Nil(t, limiter.Wait(context.Background()))
+
+			errs := make(chan error, 1)
+			go func() { errs <- limiter.Wait(context.Background()) }()
+
+			assert.Eventually(t, func() bool { return len(clock.GetTimerArgs()) == 1 }, time.Second, time.Millisecond)
+			assert.Never(t, func() bool { return len(errs) > 0 }, 50*time.Millisecond, time.Millisecond)
+
+			clock.Advance(time.Second)
+			assert.Nil(t, <-errs)
+		})
+		t.Run("canceled context", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 1)
+			assert.True(t, limiter.Allow())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errs := make(chan error, 1)
+			go func() { errs <- limiter.Wait(ctx) }()
+
+			assert.Eventually(t, func() bool { return len(clock.GetTimerArgs()) == 1 }, time.Second, time.Millisecond)
+			cancel()
+			assert.Equal(t, context.Canceled, <-errs)
+
+			// Canceled wait returns its token
+			clock.Advance(time.Second)
+			assert.True(t, limiter.Allow())
+		})
+		t.Run("exceeds burst", func(t *testing.T) {
+			clock := glock.NewMockClockAt(time.Unix(0, 0))
+			limiter := NewLimiter(clock, 1, 1)
+
+			assert.Equal(t, ErrExceedsBurst, limiter.WaitN(context.Background(), 2))
+		})
+	})
+	t.Run("set limit", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		limiter := NewLimiter(clock, 1, 10)
+		assert.True(t, limiter.AllowN(10))
+
+		clock.Advance(time.Second)
+		limiter.SetLimit(4)
+		assert.Equal(t, Limit(4), limiter.Limit())
+
+		clock.Advance(time.Second)
+		assert.True(t, limiter.AllowN(5))
+		assert.False(t, limiter.Allow())
+	})
+	t.Run("set burst", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		limiter := NewLimiter(clock, 1, 10)
+
+		limiter.SetBurst(2)
+		assert.Equal(t, 2, limiter.Burst())
+		assert.True(t, limiter.AllowN(2))
+		assert.False(t, limiter.Allow())
+
+		clock.Advance(time.Hour)
+		assert.False(t, limiter.AllowN(3))
+		assert.True(t, limiter.AllowN(2))
+	})
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// SlidingWindow is a sliding-window counter that allows at most limit events
+// in any window-sized span of time. Counts are tracked for the current and
+// previous fixed windows, and the previous window's count is weighted by how
+// much of it still overlaps the sliding window.
+type SlidingWindow struct {
+	clock  glock.Clock
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	start  time.Time
+	curr   int
+	prev   int
+}
+
+// NewSlidingWindow creates a new SlidingWindow permitting limit events within
+// each span of the given window duration.
+func NewSlidingWindow(clock glock.Clock, limit int, window time.Duration) *SlidingWindow {
+	if window <= 0 {
+		panic("non-positive window for NewSlidingWindow")
+	}
+
+	return &SlidingWindow{
+		clock:  clock,
+		limit:  limit,
+		window: window,
+		start:  clock.Now().Truncate(window),
+	}
+}
+
+// Allow reports whether an event may happen now. If so, the event is counted.
+func (w *SlidingWindow) Allow() bool {
+	return w.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now. If so, the events are counted.
+func (w *SlidingWindow) AllowN(n int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.estimate(w.clock.Now())+float64(n) > float64(w.limit) {
+		return false
+	}
+
+	w.curr += n
+	return true
+}
+
+// Count returns the estimated number of events within the sliding window
+// ending at the current time.
+func (w *SlidingWindow) Count() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.estimate(w.clock.Now())
+}
+
+// estimate rolls the fixed windows forward to contain the given time and
+// returns the weighted event count. This method assumes the lock is held.
+func (w *SlidingWindow) estimate(now time.Time) float64 {
+	if elapsed := now.Sub(w.start); elapsed >= w.window {
+		if elapsed >= 2*w.window {
+			w.prev = 0
+		} else {
+			w.prev = w.curr
+		}
+
+		w.curr = 0
+		w.start = now.Truncate(w.window)
+	}
+
+	overlap := 1 - float64(now.Sub(w.start))/float64(w.window)
+	return float64(w.prev)*overlap + float64(w.curr)
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("limits events in window", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		window := NewSlidingWindow(clock, 3, 10*time.Second)
+
+		assert.True(t, window.Allow())
+		assert.True(t, window.AllowN(2))
+		assert.False(t, window.Allow())
+		assert.Equal(t, float64(3), window.Count())
+	})
+	t.Run("weights previous window", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		window := NewSlidingWindow(clock, 4, 10*time.Second)
+		assert.True(t, window.AllowN(4))
+
+		// Half of the previous window still overlaps
+		clock.Advance(15 * time.Second)
+		assert.Equal(t, float64(2), window.Count())
+		assert.True(t, window.AllowN(2))
+		assert.False(t, window.Allow())
+
+		// A quarter of the previous window still overlaps
+		clock.Advance(2500 * time.Millisecond)
+		assert.Equal(t, float64(3), window.Count())
+		assert.True(t, window.Allow())
+		assert.False(t, window.Allow())
+	})
+	t.Run("forgets old windows", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		window := NewSlidingWindow(clock, 4, 10*time.Second)
+		assert.True(t, window.AllowN(4))
+
+		clock.Advance(25 * time.Second)
+		assert.Equal(t, float64(0), window.Count())
+		assert.True(t, window.AllowN(4))
+	})
+	t.Run("non-positive window", func(t *testing.T) {
+		assert.Panics(t, func() { NewSlidingWindow(glock.NewMockClock(), 1, 0) })
+	})
+}
@@ -0,0 +1,330 @@
+package glock
+
+import (
+	"encoding/json"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordedOp identifies the kind of a RecordedEvent.
+type RecordedOp string
+
+const (
+	// OpNow records a read of the current time via Now, Since, or Until.
+	OpNow RecordedOp = "now"
+
+	// OpSleep records the start of a call to Sleep.
+	OpSleep RecordedOp = "sleep"
+
+	// OpWake records the return of a call to Sleep.
+	OpWake RecordedOp = "wake"
+
+	// OpAfter records a call to After.
+	OpAfter RecordedOp = "after"
+
+	// OpNewTimer records the creation of a timer via NewTimer.
+	OpNewTimer RecordedOp = "new_timer"
+
+	// OpAfterFunc records the creation of a timer via AfterFunc.
+	OpAfterFunc RecordedOp = "after_func"
+
+	// OpNewTicker records the creation of a ticker via NewTicker.
+	OpNewTicker RecordedOp = "new_ticker"
+
+	// OpReset records a call to Reset on a timer.
+	OpReset RecordedOp = "reset"
+
+	// OpStop records a call to Stop on a timer or ticker.
+	OpStop RecordedOp = "stop"
+
+	// OpFire records the delivery of a value from an After channel, a
+	// timer, or a ticker, or the invocation of an AfterFunc callback.
+	OpFire RecordedOp = "fire"
+)
+
+// RecordedEvent is a single entry in the log written by a RecordingClock.
+// Each event is written as one line of JSON.
+type RecordedEvent struct {
+	// Seq is the position of the event in the log, starting at one.
+	Seq int64 `json:"seq"`
+
+	// Op identifies the kind of event.
+	Op RecordedOp `json:"op"`
+
+	// Time is the time read from the underlying clock when the event
+	// occurred.
+	Time time.Time `json:"time"`
+
+	// ID identifies the After channel, timer, or ticker that the event
+	// refers to. Fire, reset, and stop events share the ID of the event
+	// that created the channel, timer, or ticker.
+	ID int64 `json:"id,omitempty"`
+
+	// Duration is the duration argument of the call, if any.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Label is the label of the clock view on which the call was made.
+	Label string `json:"label,omitempty"`
+
+	// Caller is the name of the function that made the call. Fire events
+	// have no caller.
+	Caller string `json:"caller,omitempty"`
+}
+
+// RecordingClock is an implementation of Clock that delegates to another
+// clock (by default, a real clock) and logs every call, as well as every
+// timer and ticker firing, to an io.Writer in a JSON-lines format. The log
+// can be fed to NewReplayClock to reproduce the observed timing.
+type RecordingClock struct {
+	*recorder
+	label string
+}
+
+type recorder struct {
+	base   Clock
+	mu     sync.Mutex
+	enc    *json.Encoder
+	seq    int64
+	nextID int64
+	err    error
+}
+
+var _ Clock = &RecordingClock{}
+
+// NewRecordingClock creates a new RecordingClock that wraps a real clock and
+// writes its log to w.
+func NewRecordingClock(w io.Writer) *RecordingClock {
+	return NewRecordingClockFrom(NewRealClock(), w)
+}
+
+// NewRecordingClockFrom creates a new RecordingClock that wraps the given
+// clock and writes its log to w.
+func NewRecordingClockFrom(base Clock, w io.Writer) *RecordingClock {
+	return &RecordingClock{
+		recorder: &recorder{
+			base: base,
+			enc:  json.NewEncoder(w),
+		},
+	}
+}
+
+// Labeled returns a view of the clock that shares the same log but marks
+// every event it produces with the given label. Labels can be used to
+// distinguish the goroutines or components that use the clock.
+func (c *RecordingClock) Labeled(label string) *RecordingClock {
+	return &RecordingClock{recorder: c.recorder, label: label}
+}
+
+// Err returns the first error encountered while writing the log.
+func (c *RecordingClock) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// Now returns the underlying clock's current time.
+func (c *RecordingClock) Now() time.Time {
+	now := c.base.Now()
+	c.record(RecordedEvent{Op: OpNow, Time: now, Label: c.label, Caller: caller()})
+	return now
+}
+
+// After returns a channel that receives the current time once the given
+// duration elapses on the underlying clock.
+func (c *RecordingClock) After(duration time.Duration) <-chan time.Time {
+	id := c.newID()
+	c.record(RecordedEvent{Op: OpAfter, Time: c.base.Now(), ID: id, Duration: duration, Label: c.label, Caller: caller()})
+
+	base := c.base.After(duration)
+	ch := make(chan time.Time, 1)
+
+	go func() {
+		now := <-base
+		c.record(RecordedEvent{Op: OpFire, Time: now, ID: id, Label: c.label})
+		ch <- now
+	}()
+
+	return ch
+}
+
+// Sleep blocks until the given duration elapses on the underlying clock.
+func (c *RecordingClock) Sleep(duration time.Duration) {
+	c.record(RecordedEvent{Op: OpSleep, Time: c.base.Now(), Duration: duration, Label: c.label, Caller: caller()})
+	c.base.Sleep(duration)
+	c.record(RecordedEvent{Op: OpWake, Time: c.base.Now(), Label: c.label})
+}
+
+// Since returns the time elapsed since t.
+func (c *RecordingClock) Since(t time.Time) time.Duration {
+	now := c.base.Now()
+	c.record(RecordedEvent{Op: OpNow, Time: now, Label: c.label, Caller: caller()})
+	return now.Sub(t)
+}
+
+// Until returns the duration until t.
+func (c *RecordingClock) Until(t time.Time) time.Duration {
+	now := c.base.Now()
+	c.record(RecordedEvent{Op: OpNow, Time: now, Label: c.label, Caller: caller()})
+	return t.Sub(now)
+}
+
+// NewTicker creates a new Ticker on the underlying clock whose creation,
+// ticks, and stop are recorded.
+func (c *RecordingClock) NewTicker(duration time.Duration) Ticker {
+	id := c.newID()
+	c.record(RecordedEvent{Op: OpNewTicker, Time: c.base.Now(), ID: id, Duration: duration, Label: c.label, Caller: caller()})
+
+	t := &recordingTicker{
+		clock:  c,
+		id:     id,
+		ticker: c.base.NewTicker(duration),
+		ch:     make(chan time.Time, 1),
+		done:   make(chan struct{}),
+	}
+
+	go t.process()
+	return t
+}
+
+// NewTimer creates a new Timer on the underlying clock whose creation,
+// firing, resets, and stops are recorded.
+func (c *RecordingClock) NewTimer(duration time.Duration) Timer {
+	id := c.newID()
+	c.record(RecordedEvent{Op: OpNewTimer, Time: c.base.Now(), ID: id, Duration: duration, Label: c.label, Caller: caller()})
+
+	ch := make(chan time.Time, 1)
+	return &recordingTimer{
+		clock: c,
+		id:    id,
+		ch:    ch,
+		timer: c.base.AfterFunc(duration, func() {
+			now := c.base.Now()
+			c.record(RecordedEvent{Op: OpFire, Time: now, ID: id, Label: c.label})
+
+			select {
+			case ch <- now:
+			default:
+			}
+		}),
+	}
+}
+
+// AfterFunc calls f in its own goroutine once the given duration elapses on
+// the underlying clock. The call to f is recorded as it begins.
+func (c *RecordingClock) AfterFunc(duration time.Duration, f func()) Timer {
+	id := c.newID()
+	c.record(RecordedEvent{Op: OpAfterFunc, Time: c.base.Now(), ID: id, Duration: duration, Label: c.label, Caller: caller()})
+
+	return &recordingTimer{
+		clock: c,
+		id:    id,
+		timer: c.base.AfterFunc(duration, func() {
+			c.record(RecordedEvent{Op: OpFire, Time: c.base.Now(), ID: id, Label: c.label})
+			f()
+		}),
+	}
+}
+
+func (r *recorder) newID() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	return r.nextID
+}
+
+func (r *recorder) record(event RecordedEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seq++
+	event.Seq = r.seq
+
+	if err := r.enc.Encode(event); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// recordingFramePrefixes match the functions of the recording clock and its
+// timers and tickers, which are skipped when determining the caller.
+var recordingFramePrefixes = []string{
+	"github.com/derision-test/glock.(*RecordingClock).",
+	"github.com/derision-test/glock.(*recordingTimer).",
+	"github.com/derision-test/glock.(*recordingTicker).",
+}
+
+// caller returns the name of the first function on the stack outside of the
+// recording clock and its timers and tickers.
+func caller() string {
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+outer:
+	for {
+		frame, more := frames.Next()
+		for _, prefix := range recordingFramePrefixes {
+			if strings.HasPrefix(frame.Function, prefix) {
+				if !more {
+					return ""
+				}
+
+				continue outer
+			}
+		}
+
+		return frame.Function
+	}
+}
+
+type recordingTimer struct {
+	clock *RecordingClock
+	id    int64
+	ch    chan time.Time
+	timer Timer
+}
+
+func (t *recordingTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *recordingTimer) Reset(duration time.Duration) bool {
+	t.clock.record(RecordedEvent{Op: OpReset, Time: t.clock.base.Now(), ID: t.id, Duration: duration, Label: t.clock.label, Caller: caller()})
+	return t.timer.Reset(duration)
+}
+
+func (t *recordingTimer) Stop() bool {
+	t.clock.record(RecordedEvent{Op: OpStop, Time: t.clock.base.Now(), ID: t.id, Label: t.clock.label, Caller: caller()})
+	return t.timer.Stop()
+}
+
+type recordingTicker struct {
+	clock  *RecordingClock
+	id     int64
+	ticker Ticker
+	ch     chan time.Time
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (t *recordingTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *recordingTicker) Stop() {
+	t.once.Do(func() {
+		t.clock.record(RecordedEvent{Op: OpStop, Time: t.clock.base.Now(), ID: t.id, Label: t.clock.label, Caller: caller()})
+		t.ticker.Stop()
+		close(t.done)
+	})
+}
+
+func (t *recordingTicker) process() {
+	forwardLatestTick(t.ticker.Chan(), t.ch, t.done, func(now time.Time) (time.Time, bool) {
+		t.clock.record(RecordedEvent{Op: OpFire, Time: now, ID: t.id, Label: t.clock.label})
+		return now, true
+	}, nil)
+}
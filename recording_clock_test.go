@@ -0,0 +1,163 @@
+package glock
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("now", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(10, 0))
+		buf := &syncBuffer{}
+		clock := NewRecordingClockFrom(mock, buf)
+
+		assert.Equal(t, time.Unix(10, 0), clock.Now())
+		mock.Advance(time.Second)
+		assert.Equal(t, 6*time.Second, clock.Since(time.Unix(5, 0)))
+		assert.Equal(t, 4*time.Second, clock.Labeled("worker").Until(time.Unix(15, 0)))
+
+		events := readEvents(t, buf)
+		require.Len(t, events, 3)
+		assert.Equal(t, RecordedEvent{Seq: 1, Op: OpNow, Time: time.Unix(10, 0).UTC(), Caller: "github.com/derision-test/glock.TestRecordingClock.func1"}, events[0])
+		assert.Equal(t, time.Unix(11, 0).UTC(), events[1].Time)
+		assert.Equal(t, "worker", events[2].Label)
+		assert.Nil(t, clock.Err())
+	})
+	t.Run("timers", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		clock := NewRecordingClockFrom(mock, buf)
+
+		timer := clock.NewTimer(time.Second)
+		assert.True(t, timer.Reset(2*time.Second))
+
+		mock.Advance(2 * time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(2, 0)))
+		assert.False(t, timer.Stop())
+
+		assertOps(t, readEvents(t, buf), []RecordedEvent{
+			{Op: OpNewTimer, ID: 1, Duration: time.Second},
+			{Op: OpReset, ID: 1, Duration: 2 * time.Second},
+			{Op: OpFire, ID: 1},
+			{Op: OpStop, ID: 1},
+		})
+	})
+	t.Run("after and after func", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		clock := NewRecordingClockFrom(mock, buf)
+
+		called := make(chan struct{})
+		ch := clock.After(time.Second)
+		clock.AfterFunc(2*time.Second, func() { close(called) })
+
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(ch, time.Unix(1, 0)))
+		mock.Advance(time.Second)
+		eventually(t, structChanReceives(called))
+
+		assertOps(t, readEvents(t, buf), []RecordedEvent{
+			{Op: OpAfter, ID: 1, Duration: time.Second},
+			{Op: OpAfterFunc, ID: 2, Duration: 2 * time.Second},
+			{Op: OpFire, ID: 1},
+			{Op: OpFire, ID: 2},
+		})
+	})
+	t.Run("tickers", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		clock := NewRecordingClockFrom(mock, buf)
+
+		ticker := clock.NewTicker(time.Second)
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+		mock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(2, 0)))
+		ticker.Stop()
+		ticker.Stop()
+
+		assertOps(t, readEvents(t, buf), []RecordedEvent{
+			{Op: OpNewTicker, ID: 1, Duration: time.Second},
+			{Op: OpFire, ID: 1},
+			{Op: OpFire, ID: 1},
+			{Op: OpStop, ID: 1},
+		})
+	})
+	t.Run("sleep", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		clock := NewRecordingClockFrom(mock, buf)
+
+		done := make(chan struct{})
+		go func() {
+			clock.Sleep(time.Second)
+			close(done)
+		}()
+
+		mock.BlockingAdvance(time.Second)
+		eventually(t, structChanReceives(done))
+
+		events := readEvents(t, buf)
+		assertOps(t, events, []RecordedEvent{
+			{Op: OpSleep, Duration: time.Second},
+			{Op: OpWake},
+		})
+		assert.Equal(t, time.Unix(1, 0).UTC(), events[1].Time)
+	})
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Read(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func readEvents(t *testing.T, buf *syncBuffer) []RecordedEvent {
+	var events []RecordedEvent
+
+	scanner := bufio.NewScanner(bytes.NewBufferString(buf.String()))
+	for scanner.Scan() {
+		var event RecordedEvent
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// assertOps compares the op, id, and duration of the given events.
+func assertOps(t *testing.T, events []RecordedEvent, expected []RecordedEvent) {
+	var actual []RecordedEvent
+	for _, event := range events {
+		actual = append(actual, RecordedEvent{Op: event.Op, ID: event.ID, Duration: event.Duration})
+	}
+
+	assert.Equal(t, expected, actual)
+}
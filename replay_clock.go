@@ -0,0 +1,326 @@
+package glock
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ReplayClock is a MockClock driven by a log written by a RecordingClock.
+// The time reads and sleeps of each labeled view are replayed in their
+// recorded order and return the recorded values, advancing the mock clock
+// as they go. Timers, tickers, and After channels are created on the mock
+// clock with the durations supplied by the code under test, but relative to
+// the recorded time of their creation, so they fire as the replayed time
+// passes the same deadlines observed during recording. Likewise, a reset timer
+// is rearmed relative to the recorded time of the reset. Any firings that are not
+// reached by replayed time reads can be reproduced, in their recorded
+// order, with Step.
+type ReplayClock struct {
+	*MockClock
+	*replayer
+	label string
+}
+
+type replayer struct {
+	mu     sync.Mutex
+	events []RecordedEvent
+	nows   map[string][]time.Time
+	wakes  map[string][]time.Time
+	starts map[string][]time.Time
+	resets map[string][]time.Time
+	fires  []RecordedEvent
+}
+
+var (
+	_ Clock       = &ReplayClock{}
+	_ TickerClock = &ReplayClock{}
+)
+
+// NewReplayClock creates a new ReplayClock from the log read from r. The
+// mock clock's internal time is set to the time of the first event.
+func NewReplayClock(r io.Reader) (*ReplayClock, error) {
+	p := &replayer{
+		nows:   map[string][]time.Time{},
+		wakes:  map[string][]time.Time{},
+		starts: map[string][]time.Time{},
+		resets: map[string][]time.Time{},
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var event RecordedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, err
+		}
+
+		// Times lose their location when encoded; restore the local
+		// location that the recorded clock would have returned.
+		event.Time = event.Time.Local()
+		p.events = append(p.events, event)
+
+		switch event.Op {
+		case OpNow:
+			p.nows[event.Label] = append(p.nows[event.Label], event.Time)
+		case OpWake:
+			p.wakes[event.Label] = append(p.wakes[event.Label], event.Time)
+		case OpAfter, OpNewTimer, OpAfterFunc, OpNewTicker:
+			p.starts[event.Label] = append(p.starts[event.Label], event.Time)
+		case OpReset:
+			p.resets[event.Label] = append(p.resets[event.Label], event.Time)
+		case OpFire:
+			p.fires = append(p.fires, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	start := time.Time{}
+	if len(p.events) > 0 {
+		start = p.events[0].Time
+	}
+
+	return &ReplayClock{
+		MockClock: NewMockClockAt(start),
+		replayer:  p,
+	}, nil
+}
+
+// Labeled returns a view of the clock that replays the events recorded by
+// the view of the recording clock with the same label.
+func (c *ReplayClock) Labeled(label string) *ReplayClock {
+	return &ReplayClock{MockClock: c.MockClock, replayer: c.replayer, label: label}
+}
+
+// Events returns all events read from the log.
+func (c *ReplayClock) Events() []RecordedEvent {
+	events := make([]RecordedEvent, len(c.events))
+	copy(events, c.events)
+	return events
+}
+
+// Now returns the next recorded time read for this view's label. Once the
+// recorded reads are exhausted, the mock clock's internal time is returned.
+func (c *ReplayClock) Now() time.Time {
+	c.mu.Lock()
+	now, ok := pop(c.nows, c.label)
+	c.mu.Unlock()
+
+	if ok {
+		c.advanceTo(now)
+		return now
+	}
+
+	return c.MockClock.Now()
+}
+
+// Since returns the time elapsed since t, as read by Now.
+func (c *ReplayClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration until t, as read by Now.
+func (c *ReplayClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// Sleep returns once the mock clock has reached the recorded wake time of
+// the next sleep for this view's label. Once the recorded sleeps are
+// exhausted, Sleep blocks until the mock clock is advanced by the given
+// duration.
+func (c *ReplayClock) Sleep(duration time.Duration) {
+	c.mu.Lock()
+	wake, ok := pop(c.wakes, c.label)
+	c.mu.Unlock()
+
+	if ok {
+		c.advanceTo(wake)
+	} else {
+		c.MockClock.Sleep(duration)
+	}
+}
+
+// After returns a channel that receives the mock clock's time once it
+// passes the given duration after the recorded creation time of the next
+// After channel, timer, or ticker for this view's label.
+func (c *ReplayClock) After(duration time.Duration) <-chan time.Time {
+	start, ok := c.popStart()
+	if !ok {
+		return c.MockClock.After(duration)
+	}
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.afterArgs = append(c.afterArgs, duration)
+
+	ch := make(chan time.Time, 1)
+	subscriber := &afterSubscriber{ch: ch, deadline: start.Add(duration)}
+	if !c.now.Before(subscriber.deadline) {
+		// The rebased deadline has passed; report it as MockClock.After
+		// does for a non-positive duration
+		c.announce(subscriber)
+		subscriber.signal(c.now)
+		return ch
+	}
+
+	c.register(subscriber)
+	return ch
+}
+
+// NewTimer creates a new Timer on the mock clock whose deadline is relative
+// to the recorded creation time of the next After channel, timer, or ticker
+// for this view's label.
+func (c *ReplayClock) NewTimer(duration time.Duration) Timer {
+	start, ok := c.popStart()
+	if !ok {
+		return c.MockClock.NewTimer(duration)
+	}
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.timerArgs = append(c.timerArgs, duration)
+
+	return &replayTimer{MockTimer: newMockTimerAt(c.advanceable, start.Add(duration), sendTime), clock: c}
+}
+
+// AfterFunc creates a new Timer on the mock clock whose deadline is relative
+// to the recorded creation time of the next After channel, timer, or ticker
+// for this view's label.
+func (c *ReplayClock) AfterFunc(duration time.Duration, f func()) Timer {
+	start, ok := c.popStart()
+	if !ok {
+		return c.MockClock.AfterFunc(duration, f)
+	}
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	return &replayTimer{MockTimer: c.newAfterFunc(duration, start.Add(duration), f), clock: c}
+}
+
+// NewTicker creates a new Ticker on the mock clock whose ticks are relative
+// to the recorded creation time of the next After channel, timer, or ticker
+// for this view's label.
+func (c *ReplayClock) NewTicker(duration time.Duration) Ticker {
+	start, ok := c.popStart()
+	if !ok {
+		return c.MockClock.NewTicker(duration)
+	}
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.tickerArgs = append(c.tickerArgs, duration)
+
+	return newVariantMockTicker(c.advanceable, duration, nil, start.Add(duration))
+}
+
+// NewTickerImmediate creates a ticker that ticks once immediately and then at
+// the given interval. As a RecordingClock does not implement TickerClock, the
+// recorded ticker was built on the recording clock's timers, so the replayed
+// ticker is built the same way on this clock's timers.
+func (c *ReplayClock) NewTickerImmediate(duration time.Duration) Ticker {
+	return newVariantTicker(c, duration, nil, true)
+}
+
+// NewJitteredTicker creates a ticker with jittered intervals that is built on
+// this clock's timers (see NewTickerImmediate).
+func (c *ReplayClock) NewJitteredTicker(duration time.Duration, jitter float64, r *rand.Rand) Ticker {
+	return newVariantTicker(c, duration, jitteredInterval(duration, jitter, r), false)
+}
+
+// NewPolicyTicker creates a policy ticker that is built on this clock's timers
+// (see NewTickerImmediate).
+func (c *ReplayClock) NewPolicyTicker(duration time.Duration, policy TickPolicy) PolicyTicker {
+	return newTimerPolicyTicker(c, duration, policy)
+}
+
+// Step advances the mock clock to the time of the next recorded firing. It
+// returns the event describing the firing, or false if all recorded firings
+// have been replayed.
+func (c *ReplayClock) Step() (RecordedEvent, bool) {
+	c.mu.Lock()
+	if len(c.fires) == 0 {
+		c.mu.Unlock()
+		return RecordedEvent{}, false
+	}
+
+	event := c.fires[0]
+	c.fires = c.fires[1:]
+	c.mu.Unlock()
+
+	c.advanceTo(event.Time)
+	return event, true
+}
+
+// Remaining returns the number of recorded firings not yet replayed by Step.
+func (c *ReplayClock) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.fires)
+}
+
+// advanceTo moves the mock clock forward to the given time. The mock clock
+// never moves backwards, as reads from different labels may be replayed in
+// a different order than they were recorded. This method must be called
+// without the replayer's lock held, as hooks and synchronous AfterFunc
+// callbacks run by the advance may call back into the replay clock.
+func (c *ReplayClock) advanceTo(t time.Time) {
+	c.m.Lock()
+	if t.After(c.now) {
+		c.setCurrent(t)
+	}
+	c.unlockAndDispatch()
+}
+
+// popStart returns the recorded creation time of the next After channel,
+// timer, or ticker for this view's label.
+func (c *ReplayClock) popStart() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return pop(c.starts, c.label)
+}
+
+// replayTimer is a mock timer created relative to a recorded creation time.
+type replayTimer struct {
+	*MockTimer
+	clock *ReplayClock
+}
+
+// Reset resets the timer to fire the given duration after the recorded time
+// of the next reset for the clock view's label. Once the recorded resets are
+// exhausted, the duration is relative to the mock clock's internal time.
+func (t *replayTimer) Reset(duration time.Duration) bool {
+	t.clock.mu.Lock()
+	start, ok := pop(t.clock.resets, t.clock.label)
+	t.clock.mu.Unlock()
+
+	if !ok {
+		return t.MockTimer.Reset(duration)
+	}
+
+	t.cond.L.Lock()
+	return t.resetLocked(start.Add(duration))
+}
+
+func pop(queues map[string][]time.Time, label string) (time.Time, bool) {
+	queue := queues[label]
+	if len(queue) == 0 {
+		return time.Time{}, false
+	}
+
+	queues[label] = queue[1:]
+	return queue[0], true
+}
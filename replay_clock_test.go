@@ -0,0 +1,207 @@
+package glock
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("replays recorded session", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(100, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+
+		// Record a session with times read from two labeled views
+		main := recording.Labeled("main")
+		worker := recording.Labeled("worker")
+		main.Now()
+		timer := main.NewTimer(3 * time.Second)
+		mock.Advance(time.Second)
+		worker.Now()
+		mock.Advance(2 * time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(103, 0)))
+		mock.Advance(time.Second)
+		main.Now()
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+		assert.Len(t, replay.Events(), 5)
+		assert.Equal(t, time.Unix(100, 0), replay.MockClock.Now())
+
+		// Replay with views in a different order
+		replayMain := replay.Labeled("main")
+		replayWorker := replay.Labeled("worker")
+		assert.Equal(t, time.Unix(101, 0), replayWorker.Now())
+		assert.Equal(t, time.Unix(100, 0), replayMain.Now())
+		replayTimer := replayMain.NewTimer(3 * time.Second)
+		consistently(t, chanDoesNotReceive(replayTimer.Chan()))
+
+		event, ok := replay.Step()
+		require.True(t, ok)
+		assert.Equal(t, OpFire, event.Op)
+		eventually(t, chanReceives(replayTimer.Chan(), time.Unix(103, 0)))
+
+		_, ok = replay.Step()
+		assert.False(t, ok)
+		assert.Equal(t, 0, replay.Remaining())
+
+		assert.Equal(t, time.Unix(104, 0), replayMain.Now())
+
+		// Recorded reads are exhausted; fall back to the mock time
+		assert.Equal(t, time.Unix(104, 0), replayMain.Now())
+	})
+	t.Run("replays sleeps", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+
+		done := make(chan struct{})
+		go func() {
+			recording.Sleep(time.Second)
+			close(done)
+		}()
+		mock.BlockingAdvance(5 * time.Second)
+		eventually(t, structChanReceives(done))
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		replay.Sleep(time.Second)
+		assert.Equal(t, time.Unix(5, 0), replay.MockClock.Now())
+	})
+	t.Run("rebases timers before registering them", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+		recording.NewTimer(3 * time.Second).Stop()
+		recording.NewTicker(4 * time.Second).Stop()
+		recording.AfterFunc(time.Second, func() {}).Stop()
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		var events []PendingEvent
+		replay.OnRegister(func(e PendingEvent) { events = append(events, e) })
+		replay.SetCurrent(time.Unix(2, 0))
+
+		called := make(chan struct{})
+		timer := replay.NewTimer(3 * time.Second)
+		ticker := replay.NewTicker(4 * time.Second)
+		defer ticker.Stop()
+
+		// The callback's rebased deadline has passed, so it is called
+		// without waiting for the clock to advance
+		replay.AfterFunc(time.Second, func() { close(called) })
+		eventually(t, structChanReceives(called))
+
+		assert.Equal(t, []PendingEvent{
+			{ID: 1, Kind: TimerEvent, Deadline: time.Unix(3, 0)},
+			{ID: 2, Kind: TickerEvent, Deadline: time.Unix(4, 0)},
+			{ID: 3, Kind: AfterFuncEvent, Deadline: time.Unix(1, 0)},
+		}, events)
+
+		replay.Advance(time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(3, 0)))
+	})
+	t.Run("reports already due after channels to hooks", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+		recording.After(time.Second)
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		var registered []PendingEvent
+		var fired []FiredEvent
+		replay.OnRegister(func(e PendingEvent) { registered = append(registered, e) })
+		replay.OnFire(func(e FiredEvent) { fired = append(fired, e) })
+		replay.SetCurrent(time.Unix(2, 0))
+
+		ch := replay.After(time.Second)
+		eventually(t, chanReceives(ch, time.Unix(1, 0)))
+		assert.Equal(t, []PendingEvent{{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0)}}, registered)
+		assert.Equal(t, []FiredEvent{{ID: 1, Kind: AfterEvent, Deadline: time.Unix(1, 0), Time: time.Unix(2, 0)}}, fired)
+	})
+	t.Run("replays resets", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+		timer := recording.NewTimer(5 * time.Second)
+		mock.Advance(2 * time.Second)
+		timer.Reset(5 * time.Second)
+		mock.Advance(5 * time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(7, 0)))
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		// The timer is reset before the replayed time reaches the time of
+		// the recorded reset
+		replayTimer := replay.NewTimer(5 * time.Second)
+		assert.True(t, replayTimer.Reset(5*time.Second))
+		deadline, ok := replay.NextDeadline()
+		require.True(t, ok)
+		assert.Equal(t, time.Unix(7, 0), deadline)
+
+		event, ok := replay.Step()
+		require.True(t, ok)
+		assert.Equal(t, time.Unix(7, 0), event.Time)
+		eventually(t, chanReceives(replayTimer.Chan(), time.Unix(7, 0)))
+	})
+	t.Run("replays ticker variants built on timers", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(0, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+		NewTickerImmediateOn(recording, 2*time.Second).Stop()
+		NewJitteredTickerOn(recording, 2*time.Second, 0.1, rand.New(rand.NewSource(1))).Stop()
+		NewPolicyTickerOn(recording, 2*time.Second, DropMissedTicks).Stop()
+		mock.Advance(time.Second)
+		recording.NewTimer(3 * time.Second)
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		NewTickerImmediateOn(replay, 2*time.Second).Stop()
+		NewJitteredTickerOn(replay, 2*time.Second, 0.1, rand.New(rand.NewSource(1))).Stop()
+		NewPolicyTickerOn(replay, 2*time.Second, DropMissedTicks).Stop()
+
+		// The tickers consumed the recorded creation times of their
+		// timers, so the timer is rebased on its own creation time
+		var events []PendingEvent
+		replay.OnRegister(func(e PendingEvent) { events = append(events, e) })
+		replay.NewTimer(3 * time.Second)
+		require.Len(t, events, 1)
+		assert.Equal(t, time.Unix(4, 0), events[0].Deadline)
+	})
+	t.Run("hooks can call back into the replay clock", func(t *testing.T) {
+		mock := NewMockClockAt(time.Unix(100, 0))
+		buf := &syncBuffer{}
+		recording := NewRecordingClockFrom(mock, buf)
+		recording.Now()
+		mock.Advance(2 * time.Second)
+		recording.Now()
+
+		replay, err := NewReplayClock(bytes.NewBufferString(buf.String()))
+		require.Nil(t, err)
+
+		var hooked []time.Time
+		replay.OnAdvance(func(old, new time.Time) { hooked = append(hooked, replay.Now()) })
+
+		assert.Equal(t, time.Unix(100, 0), replay.Now())
+		assert.Equal(t, time.Unix(102, 0), replay.Now())
+		assert.Equal(t, []time.Time{time.Unix(102, 0)}, hooked)
+	})
+	t.Run("invalid log", func(t *testing.T) {
+		_, err := NewReplayClock(strings.NewReader("{not json}\n"))
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+//go:build go1.21
+
+package glock
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// slogHandler is a slog.Handler that stamps records with the time read from a
+// Clock before passing them to the wrapped handler.
+type slogHandler struct {
+	inner slog.Handler
+	clock Clock
+}
+
+// NewSlogHandler returns a slog.Handler that replaces the time of each record
+// with the current time of the given clock before passing it to the inner
+// handler. Records with a zero time are passed through unchanged, as handlers
+// omit the time of such records.
+func NewSlogHandler(inner slog.Handler, clock Clock) slog.Handler {
+	return &slogHandler{inner: inner, clock: clock}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !r.Time.IsZero() {
+		r.Time = h.clock.Now()
+	}
+
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogHandler{inner: h.inner.WithAttrs(attrs), clock: h.clock}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{inner: h.inner.WithGroup(name), clock: h.clock}
+}
+
+// SinceAttr returns a duration attribute with the given key holding the time
+// elapsed since start, as measured by the given clock.
+func SinceAttr(clock Clock, key string, start time.Time) slog.Attr {
+	return slog.Duration(key, clock.Since(start))
+}
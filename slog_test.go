@@ -0,0 +1,43 @@
+//go:build go1.21
+
+package glock
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogHandler(t *testing.T) {
+	clock := NewMockClockAt(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(slog.NewTextHandler(&buf, nil), clock))
+
+	start := clock.Now()
+	clock.Advance(1500 * time.Millisecond)
+	logger.With("request", 1).WithGroup("job").Info("finished", SinceAttr(clock, "elapsed", start))
+
+	assert.Equal(t, "time=2024-01-02T03:04:06.500Z level=INFO msg=finished request=1 job.elapsed=1.5s\n", buf.String())
+}
+
+func TestSlogHandlerZeroTime(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSlogHandler(slog.NewTextHandler(&buf, nil), NewMockClock())
+
+	record := slog.NewRecord(time.Time{}, slog.LevelInfo, "no time", 0)
+	assert.NoError(t, handler.Handle(context.Background(), record))
+	assert.Equal(t, "level=INFO msg=\"no time\"\n", buf.String())
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	inner := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn})
+	handler := NewSlogHandler(inner, NewMockClock())
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError))
+}
@@ -0,0 +1,107 @@
+package glock
+
+import "time"
+
+// restorable is implemented by subscribers whose state can be captured by
+// MockClock.Snapshot and rewound by MockClock.Restore.
+type restorable interface {
+	subscriber
+
+	// save returns a function that rewinds the subscriber to its current
+	// state and returns whether it is still pending. Both are called with
+	// the lock held.
+	save() (restore func() bool)
+
+	// cancel permanently deactivates a subscriber that was created after
+	// the snapshot being restored. This method is called with the lock held.
+	cancel()
+}
+
+// Snapshot is the state of a MockClock at a point in time. See MockClock.Snapshot.
+type Snapshot struct {
+	clock       *MockClock
+	now         time.Time
+	afterArgs   []time.Duration
+	tickerArgs  []time.Duration
+	timerArgs   []time.Duration
+	deferred    []dueCallback
+	subscribers []restorable
+	restores    []func() bool
+}
+
+// Snapshot captures the clock's current time, its logs of call arguments, and
+// the state of its pending After channels, timers, and tickers, so that the
+// clock can later be rewound to this moment with Restore. A snapshot can be
+// restored any number of times.
+func (c *MockClock) Snapshot() *Snapshot {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	snap := &Snapshot{
+		clock:      c,
+		now:        c.now,
+		afterArgs:  append([]time.Duration(nil), c.afterArgs...),
+		tickerArgs: append([]time.Duration(nil), c.tickerArgs...),
+		timerArgs:  append([]time.Duration(nil), c.timerArgs...),
+		deferred:   append([]dueCallback(nil), c.deferred...),
+	}
+
+	for _, s := range c.subscribers {
+		if r, ok := s.(restorable); ok {
+			snap.subscribers = append(snap.subscribers, r)
+			snap.restores = append(snap.restores, r.save())
+		}
+	}
+
+	return snap
+}
+
+// Restore rewinds the clock to the given snapshot, which must have been taken
+// from this clock. The time and the logs of call arguments are reset. Timers and
+// tickers that were pending at the snapshot are re-armed with their deadlines at
+// the time, even if they have fired or been stopped since, and values delivered
+// since the snapshot by After channels are discarded. After channels, timers,
+// and tickers created since the snapshot are canceled and never fire. The
+// OnRegister hooks are called for each re-armed event.
+//
+// Goroutines are not rewound: a goroutine that has returned from Sleep or
+// received from a channel since the snapshot does not do so again.
+func (c *MockClock) Restore(snap *Snapshot) {
+	if snap.clock != c {
+		panic("glock: snapshot restored to a different clock")
+	}
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	saved := make(map[subscriber]struct{}, len(snap.subscribers))
+	for _, s := range snap.subscribers {
+		saved[s] = struct{}{}
+	}
+
+	for _, s := range c.subscribers {
+		if _, ok := saved[s]; ok {
+			continue
+		}
+
+		if r, ok := s.(restorable); ok {
+			r.cancel()
+		}
+	}
+
+	c.now = snap.now
+	c.afterArgs = append([]time.Duration(nil), snap.afterArgs...)
+	c.tickerArgs = append([]time.Duration(nil), snap.tickerArgs...)
+	c.timerArgs = append([]time.Duration(nil), snap.timerArgs...)
+	c.deferred = append([]dueCallback(nil), snap.deferred...)
+
+	c.subscribers = c.subscribers[:0]
+	for i, restore := range snap.restores {
+		if restore() {
+			c.subscribers = append(c.subscribers, snap.subscribers[i])
+			c.announce(snap.subscribers[i])
+		}
+	}
+
+	c.cond.Broadcast()
+}
@@ -0,0 +1,200 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRestoresTimeAndArgs(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.After(time.Second)
+	clock.NewTicker(2 * time.Second).Stop()
+	clock.NewTimer(3 * time.Second).Stop()
+	snap := clock.Snapshot()
+
+	clock.Advance(5 * time.Second)
+	clock.After(4 * time.Second)
+	clock.NewTicker(5 * time.Second).Stop()
+	clock.NewTimer(6 * time.Second).Stop()
+
+	clock.Restore(snap)
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	assert.Equal(t, []time.Duration{time.Second}, clock.GetAfterArgs())
+	assert.Equal(t, []time.Duration{2 * time.Second}, clock.GetTickerArgs())
+	assert.Equal(t, []time.Duration{3 * time.Second}, clock.GetTimerArgs())
+
+	// A snapshot can be restored more than once
+	clock.Advance(time.Second)
+	clock.Restore(snap)
+	assert.Equal(t, time.Unix(0, 0), clock.Now())
+	assert.Equal(t, []time.Duration{time.Second}, clock.GetAfterArgs())
+}
+
+func TestSnapshotRestoreDoesNotAliasArgs(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	clock.After(5 * time.Second)
+	clock.NewTicker(5 * time.Second).Stop()
+	snap := clock.Snapshot()
+	clock.GetAfterArgs()
+	clock.GetTickerArgs()
+
+	clock.After(time.Second)
+	clock.After(2 * time.Second)
+	clock.NewTicker(time.Second).Stop()
+	clock.NewTicker(2 * time.Second).Stop()
+	afterArgs := clock.GetAfterArgs()
+	tickerArgs := clock.GetTickerArgs()
+
+	clock.Restore(snap)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, afterArgs)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, tickerArgs)
+}
+
+func TestSnapshotRestoresTimer(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	timer := clock.NewTimer(2 * time.Second)
+	defer timer.Stop()
+
+	clock.Advance(time.Second)
+	snap := clock.Snapshot()
+
+	for i := 0; i < 2; i++ {
+		clock.Advance(time.Second)
+		eventually(t, chanReceives(timer.Chan(), time.Unix(2, 0)))
+
+		clock.Restore(snap)
+		consistently(t, chanDoesNotReceive(timer.Chan()))
+	}
+}
+
+func TestSnapshotRestoresStoppedTimer(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	timer := clock.NewTimer(time.Second)
+	defer timer.Stop()
+
+	snap := clock.Snapshot()
+	assert.True(t, timer.Stop())
+	clock.Restore(snap)
+
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(timer.Chan(), time.Unix(1, 0)))
+}
+
+func TestSnapshotRestoresAfterFunc(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	clock := NewMockClockAt(time.Unix(0, 0), WithSynchronousAfterFunc())
+	clock.AfterFunc(time.Second, func() { calls++ })
+	snap := clock.Snapshot()
+
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, calls)
+
+	clock.Restore(snap)
+	clock.Advance(time.Second)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSnapshotRestoresAfter(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	after := clock.After(time.Second)
+	snap := clock.Snapshot()
+
+	clock.Advance(time.Second)
+	clock.Restore(snap)
+	consistently(t, chanDoesNotReceive(after))
+
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(after, time.Unix(1, 0)))
+}
+
+func TestSnapshotRestoresTicker(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+	snap := clock.Snapshot()
+
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(2, 0)))
+	ticker.Stop()
+
+	clock.Restore(snap)
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(2, 0)))
+}
+
+func TestSnapshotRestoresPolicyTicker(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, QueueMissedTicks)
+	defer ticker.Stop()
+
+	clock.Advance(2 * time.Second)
+	snap := clock.Snapshot()
+
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(1, 0)}))
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(2, 0)}))
+	ticker.Stop()
+
+	// Ticks pending at the snapshot are delivered again
+	clock.Restore(snap)
+	clock.Advance(time.Second)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(1, 0)}))
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(2, 0)}))
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(3, 0)}))
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+}
+
+func TestSnapshotCancelsNewEvents(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	snap := clock.Snapshot()
+
+	after := clock.After(time.Second)
+	timer := clock.NewTimer(time.Second)
+	ticker := clock.NewTicker(time.Second)
+	policyTicker := clock.NewPolicyTicker(time.Second, QueueMissedTicks)
+
+	clock.Restore(snap)
+	assert.Equal(t, 0, clock.BlockedOnAfter())
+	_, ok := clock.NextDeadline()
+	assert.False(t, ok)
+
+	clock.Advance(time.Second)
+	consistently(t, chanDoesNotReceive(after))
+	consistently(t, chanDoesNotReceive(timer.Chan()))
+	consistently(t, chanDoesNotReceive(ticker.Chan()))
+	consistently(t, tickDoesNotReceive(policyTicker.Ticks()))
+
+	// Canceled timers can be reused
+	assert.False(t, timer.Reset(time.Second))
+	clock.Advance(time.Second)
+	eventually(t, chanReceives(timer.Chan(), time.Unix(2, 0)))
+}
+
+func TestSnapshotRestoreDifferentClock(t *testing.T) {
+	t.Parallel()
+
+	snap := NewMockClock().Snapshot()
+	assert.Panics(t, func() { NewMockClock().Restore(snap) })
+}
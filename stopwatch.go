@@ -0,0 +1,142 @@
+package glock
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch measures elapsed time using a Clock. A stopwatch accumulates
+// time only while running, so it can be stopped and started again to pause
+// and resume a measurement. Intermediate durations can be recorded as
+// labeled laps.
+type Stopwatch struct {
+	clock     Clock
+	mu        sync.Mutex
+	running   bool
+	startedAt time.Time
+	elapsed   time.Duration
+	lapMark   time.Duration
+	laps      []Lap
+}
+
+// Lap is a labeled duration recorded by a Stopwatch.
+type Lap struct {
+	Label    string
+	Duration time.Duration
+}
+
+// NewStopwatch creates a new stopped Stopwatch that reads time from the
+// given clock.
+func NewStopwatch(clock Clock) *Stopwatch {
+	return &Stopwatch{clock: clock}
+}
+
+// StartStopwatch creates a new Stopwatch that reads time from the given clock
+// and starts it immediately.
+func StartStopwatch(clock Clock) *Stopwatch {
+	s := NewStopwatch(clock)
+	s.Start()
+	return s
+}
+
+// Start starts the stopwatch. If the stopwatch was previously stopped, it
+// resumes accumulating time from its current elapsed value. Starting a
+// running stopwatch has no effect.
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		s.running = true
+		s.startedAt = s.clock.Now()
+	}
+}
+
+// Stop stops the stopwatch, returning the total elapsed time. Stopping a
+// stopped stopwatch has no effect.
+func (s *Stopwatch) Stop() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		s.elapsed += s.clock.Since(s.startedAt)
+		s.running = false
+	}
+
+	return s.elapsed
+}
+
+// Running returns true if the stopwatch is currently running.
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.running
+}
+
+// Elapsed returns the total time accumulated while the stopwatch was running.
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.current()
+}
+
+// Lap records the time accumulated since the previous lap (or since the
+// stopwatch was started, for the first lap) under the given label and
+// returns it. Time during which the stopwatch was stopped is not counted.
+func (s *Stopwatch) Lap(label string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := s.current()
+	duration := elapsed - s.lapMark
+	s.lapMark = elapsed
+	s.laps = append(s.laps, Lap{Label: label, Duration: duration})
+
+	return duration
+}
+
+// Laps returns the laps recorded since the stopwatch was created or last reset.
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	laps := make([]Lap, len(s.laps))
+	copy(laps, s.laps)
+	return laps
+}
+
+// Breakdown returns the total duration of all laps recorded under each label.
+func (s *Stopwatch) Breakdown() map[string]time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	breakdown := make(map[string]time.Duration, len(s.laps))
+	for _, lap := range s.laps {
+		breakdown[lap.Label] += lap.Duration
+	}
+
+	return breakdown
+}
+
+// Reset clears the elapsed time and recorded laps. A running stopwatch
+// continues running from zero.
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.elapsed = 0
+	s.lapMark = 0
+	s.laps = nil
+	s.startedAt = s.clock.Now()
+}
+
+// current returns the total elapsed time. This method assumes the lock is held.
+func (s *Stopwatch) current() time.Duration {
+	if s.running {
+		return s.elapsed + s.clock.Since(s.startedAt)
+	}
+
+	return s.elapsed
+}
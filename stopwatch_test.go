@@ -0,0 +1,87 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopwatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("elapsed", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		stopwatch := NewStopwatch(clock)
+		assert.False(t, stopwatch.Running())
+
+		clock.Advance(time.Second)
+		assert.Equal(t, time.Duration(0), stopwatch.Elapsed())
+
+		stopwatch.Start()
+		assert.True(t, stopwatch.Running())
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, 2*time.Second, stopwatch.Elapsed())
+
+		assert.Equal(t, 2*time.Second, stopwatch.Stop())
+		assert.False(t, stopwatch.Running())
+		clock.Advance(time.Hour)
+		assert.Equal(t, 2*time.Second, stopwatch.Elapsed())
+	})
+	t.Run("pause and resume", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		stopwatch := StartStopwatch(clock)
+
+		clock.Advance(time.Second)
+		stopwatch.Stop()
+		stopwatch.Stop()
+		clock.Advance(time.Minute)
+		stopwatch.Start()
+		stopwatch.Start()
+		clock.Advance(time.Second)
+		assert.Equal(t, 2*time.Second, stopwatch.Elapsed())
+	})
+	t.Run("laps", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		stopwatch := StartStopwatch(clock)
+
+		clock.Advance(100 * time.Millisecond)
+		assert.Equal(t, 100*time.Millisecond, stopwatch.Lap("parse"))
+
+		clock.Advance(200 * time.Millisecond)
+		stopwatch.Stop()
+		clock.Advance(time.Minute)
+		stopwatch.Start()
+		clock.Advance(50 * time.Millisecond)
+		assert.Equal(t, 250*time.Millisecond, stopwatch.Lap("query"))
+
+		clock.Advance(300 * time.Millisecond)
+		assert.Equal(t, 300*time.Millisecond, stopwatch.Lap("parse"))
+
+		assert.Equal(t, []Lap{
+			{Label: "parse", Duration: 100 * time.Millisecond},
+			{Label: "query", Duration: 250 * time.Millisecond},
+			{Label: "parse", Duration: 300 * time.Millisecond},
+		}, stopwatch.Laps())
+		assert.Equal(t, map[string]time.Duration{
+			"parse": 400 * time.Millisecond,
+			"query": 250 * time.Millisecond,
+		}, stopwatch.Breakdown())
+		assert.Equal(t, 650*time.Millisecond, stopwatch.Elapsed())
+	})
+	t.Run("reset", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		stopwatch := StartStopwatch(clock)
+
+		clock.Advance(time.Second)
+		stopwatch.Lap("a")
+		clock.Advance(time.Second)
+		stopwatch.Reset()
+		assert.Equal(t, time.Duration(0), stopwatch.Elapsed())
+		assert.Empty(t, stopwatch.Laps())
+		assert.True(t, stopwatch.Running())
+
+		clock.Advance(time.Second)
+		assert.Equal(t, time.Second, stopwatch.Lap("b"))
+	})
+}
@@ -0,0 +1,94 @@
+package glock
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttler limits invocations to at most once per interval. A call made
+// while the throttler is cooling down is deferred until the end of the
+// interval; if several calls are made during the same interval, only the
+// last one is invoked. All timing is performed with timers created by the
+// throttler's clock.
+type Throttler struct {
+	clock    Clock
+	interval time.Duration
+	mu       sync.Mutex
+	timer    Timer
+	pending  func()
+	invoked  bool
+	last     time.Time
+
+	// generation discards callbacks of stale timers (see Debouncer).
+	generation int
+}
+
+// NewThrottler creates a new Throttler that invokes calls at most once per
+// the given interval.
+func NewThrottler(clock Clock, interval time.Duration) *Throttler {
+	return &Throttler{
+		clock:    clock,
+		interval: interval,
+	}
+}
+
+// Call registers a call to f. If the throttler is not cooling down, f is
+// invoked immediately in the caller's goroutine. Otherwise, f replaces any
+// previously deferred call and is invoked in the goroutine of the underlying
+// timer at the end of the current interval.
+func (t *Throttler) Call(f func()) {
+	t.mu.Lock()
+
+	now := t.clock.Now()
+	if t.timer == nil && (!t.invoked || now.Sub(t.last) >= t.interval) {
+		t.invoked = true
+		t.last = now
+		t.mu.Unlock()
+
+		f()
+		return
+	}
+
+	t.pending = f
+
+	if t.timer == nil {
+		t.generation++
+		generation := t.generation
+		t.timer = t.clock.AfterFunc(t.last.Add(t.interval).Sub(now), func() { t.fire(generation) })
+	}
+
+	t.mu.Unlock()
+}
+
+// Cancel drops the deferred call, if any.
+func (t *Throttler) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+
+	t.generation++
+	t.timer = nil
+	t.pending = nil
+}
+
+// fire invokes the deferred call associated with the given timer generation.
+func (t *Throttler) fire(generation int) {
+	t.mu.Lock()
+	if generation != t.generation {
+		t.mu.Unlock()
+		return
+	}
+
+	f := t.pending
+	t.timer = nil
+	t.pending = nil
+	t.last = t.clock.Now()
+	t.mu.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
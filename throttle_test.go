@@ -0,0 +1,65 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invokes first call immediately", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		throttler := NewThrottler(clock, time.Second)
+
+		throttler.Call(calls.record(1))
+		assert.True(t, calls.equals(1)())
+	})
+	t.Run("defers last call in interval", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		throttler := NewThrottler(clock, time.Second)
+
+		throttler.Call(calls.record(1))
+		clock.Advance(300 * time.Millisecond)
+		throttler.Call(calls.record(2))
+		throttler.Call(calls.record(3))
+		clock.Advance(300 * time.Millisecond)
+		consistently(t, calls.equals(1))
+
+		clock.Advance(400 * time.Millisecond)
+		eventually(t, calls.equals(1, 3))
+
+		// The deferred call starts a new interval
+		throttler.Call(calls.record(4))
+		clock.Advance(999 * time.Millisecond)
+		consistently(t, calls.equals(1, 3))
+		clock.Advance(time.Millisecond)
+		eventually(t, calls.equals(1, 3, 4))
+	})
+	t.Run("invokes immediately after interval", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		throttler := NewThrottler(clock, time.Second)
+
+		throttler.Call(calls.record(1))
+		clock.Advance(time.Second)
+		throttler.Call(calls.record(2))
+		assert.True(t, calls.equals(1, 2)())
+	})
+	t.Run("cancel", func(t *testing.T) {
+		clock := NewMockClockAt(time.Unix(0, 0))
+		calls := newCallRecorder()
+		throttler := NewThrottler(clock, time.Second)
+
+		throttler.Call(calls.record(1))
+		throttler.Call(calls.record(2))
+		throttler.Cancel()
+
+		clock.Advance(time.Second)
+		consistently(t, calls.equals(1))
+	})
+}
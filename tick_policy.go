@@ -0,0 +1,361 @@
+package glock
+
+import (
+	"sync"
+	"time"
+)
+
+// TickPolicy determines what a PolicyTicker does with ticks that elapse while
+// an earlier tick has not yet been received.
+type TickPolicy int
+
+const (
+	// DropMissedTicks drops ticks that elapse while a tick is pending, as
+	// time.Ticker does.
+	DropMissedTicks TickPolicy = iota
+
+	// CoalesceMissedTicks merges ticks that elapse while a tick is pending
+	// into a single tick, counting them in its Missed field.
+	CoalesceMissedTicks
+
+	// QueueMissedTicks delivers every tick, queueing ticks that elapse while
+	// others are pending. The queue is unbounded.
+	QueueMissedTicks
+)
+
+// Tick is a value delivered by a PolicyTicker.
+type Tick struct {
+	// Time is the scheduled time of the tick. For a coalesced tick, it is
+	// the scheduled time of the latest tick merged into it.
+	Time time.Time
+
+	// Missed is the number of earlier ticks merged into this one. It is
+	// always zero unless the policy is CoalesceMissedTicks.
+	Missed int
+}
+
+// PolicyTicker is a ticker that handles missed ticks according to a TickPolicy.
+type PolicyTicker interface {
+	// Ticks returns the channel on which ticks are delivered.
+	Ticks() <-chan Tick
+
+	// Stop stops the ticker. Pending ticks are discarded.
+	Stop()
+}
+
+// PolicyTickerAsTicker adapts a PolicyTicker to the Ticker interface, so that it
+// can be passed to code that accepts a Ticker. The channel of the returned ticker
+// receives the time of each tick delivered by the policy ticker; the number of
+// ticks merged into a coalesced tick is discarded. Stopping the returned ticker
+// stops the policy ticker.
+func PolicyTickerAsTicker(t PolicyTicker) Ticker {
+	a := &policyTickerAdapter{
+		ticker: t,
+		ch:     make(chan time.Time),
+		done:   make(chan struct{}),
+	}
+
+	go a.forward()
+	return a
+}
+
+type policyTickerAdapter struct {
+	ticker PolicyTicker
+	ch     chan time.Time
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (a *policyTickerAdapter) Chan() <-chan time.Time {
+	return a.ch
+}
+
+func (a *policyTickerAdapter) Stop() {
+	a.once.Do(func() {
+		a.ticker.Stop()
+		close(a.done)
+	})
+}
+
+// forward sends the time of each tick on the adapter's channel. Ticks that are
+// not yet forwarded stay buffered by the policy ticker.
+func (a *policyTickerAdapter) forward() {
+	for {
+		select {
+		case tick := <-a.ticker.Ticks():
+			select {
+			case a.ch <- tick.Time:
+			case <-a.done:
+				return
+			}
+
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// policyTicker buffers ticks according to a policy and delivers them from its
+// own goroutine. Ticks are added by the owning real or mock ticker with the
+// lock held.
+type policyTicker struct {
+	policy  TickPolicy
+	m       *sync.Mutex
+	cond    *sync.Cond
+	pending []Tick
+	sending bool // pending[0] is being delivered and cannot be merged into
+	stopped bool
+	ch      chan Tick
+	done    chan struct{}
+
+	// generation is incremented when the pending ticks are replaced (see
+	// MockClock.Restore), so that a tick delivered concurrently is not
+	// removed from the replacement.
+	generation int
+}
+
+func newPolicyTicker(m *sync.Mutex, policy TickPolicy) *policyTicker {
+	t := &policyTicker{
+		policy: policy,
+		m:      m,
+		cond:   sync.NewCond(m),
+		ch:     make(chan Tick),
+		done:   make(chan struct{}),
+	}
+
+	go t.process(t.done)
+	return t
+}
+
+func (t *policyTicker) Ticks() <-chan Tick {
+	return t.ch
+}
+
+func (t *policyTicker) Stop() {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.stop()
+}
+
+// stop stops the ticker's delivery goroutine. This method assumes the lock is
+// held.
+func (t *policyTicker) stop() {
+	if t.stopped {
+		return
+	}
+
+	t.stopped = true
+	t.pending = nil
+	t.cond.Broadcast()
+	close(t.done)
+}
+
+// add buffers count ticks scheduled at the given interval from the given time
+// according to the ticker's policy. Only QueueMissedTicks stores each tick; the
+// other policies keep at most one pending tick, so the ticks they drop or merge
+// are accounted for without being created. This method assumes the lock is held.
+func (t *policyTicker) add(first time.Time, interval time.Duration, count int) {
+	if t.stopped || count == 0 {
+		return
+	}
+
+	switch t.policy {
+	case DropMissedTicks:
+		if len(t.pending) == 0 {
+			t.pending = append(t.pending, Tick{Time: first})
+		}
+
+	case CoalesceMissedTicks:
+		last := first.Add(time.Duration(count-1) * interval)
+
+		if n := len(t.pending); n == 0 || (n == 1 && t.sending) {
+			// The tick being delivered cannot be merged into
+			t.pending = append(t.pending, Tick{Time: last, Missed: count - 1})
+		} else {
+			t.pending[n-1].Time = last
+			t.pending[n-1].Missed += count
+		}
+
+	default:
+		for i := 0; i < count; i++ {
+			t.pending = append(t.pending, Tick{Time: first.Add(time.Duration(i) * interval)})
+		}
+	}
+
+	t.cond.Broadcast()
+}
+
+// process delivers pending ticks until the given done channel is closed or
+// replaced by a restarted ticker (see MockClock.Restore).
+func (t *policyTicker) process(done chan struct{}) {
+	for {
+		t.m.Lock()
+		for len(t.pending) == 0 && !t.stopped && t.done == done {
+			t.cond.Wait()
+		}
+		if t.stopped || t.done != done {
+			t.m.Unlock()
+			return
+		}
+
+		tick, generation := t.pending[0], t.generation
+		t.sending = true
+		t.m.Unlock()
+
+		select {
+		case t.ch <- tick:
+		case <-done:
+			return
+		}
+
+		t.m.Lock()
+		if !t.stopped && t.done == done && t.generation == generation {
+			t.pending = t.pending[1:]
+			t.sending = false
+		}
+		t.m.Unlock()
+	}
+}
+
+// elapsedDeadlines returns the number of deadlines from the given one onwards,
+// at the given interval, that are not after now, along with the first deadline
+// that is after now.
+func elapsedDeadlines(deadline time.Time, duration time.Duration, now time.Time) (int, time.Time) {
+	if now.Before(deadline) {
+		return 0, deadline
+	}
+
+	count := int(now.Sub(deadline)/duration) + 1
+	return count, deadline.Add(time.Duration(count) * duration)
+}
+
+// NewPolicyTickerOn creates a ticker on the given clock that handles missed
+// ticks according to the given policy. If the clock does not implement
+// TickerClock, the ticker is built on the clock's timers.
+func NewPolicyTickerOn(clock Clock, duration time.Duration, policy TickPolicy) PolicyTicker {
+	if c, ok := clock.(TickerClock); ok {
+		return c.NewPolicyTicker(duration, policy)
+	}
+
+	return newTimerPolicyTicker(clock, duration, policy)
+}
+
+// NewPolicyTicker creates a ticker on the real time that handles missed ticks
+// according to the given policy.
+func (c *realClock) NewPolicyTicker(duration time.Duration, policy TickPolicy) PolicyTicker {
+	return newTimerPolicyTicker(c, duration, policy)
+}
+
+// newTimerPolicyTicker creates a policy ticker that re-arms a timer of the given
+// clock for each tick.
+func newTimerPolicyTicker(clock Clock, duration time.Duration, policy TickPolicy) PolicyTicker {
+	checkTickerInterval(duration)
+
+	t := newPolicyTicker(&sync.Mutex{}, policy)
+	deadline := clock.Now().Add(duration)
+	go processTimerPolicyTicker(clock, clock.NewTimer(duration), t, deadline, duration)
+	return t
+}
+
+func processTimerPolicyTicker(clock Clock, timer Timer, t *policyTicker, deadline time.Time, duration time.Duration) {
+	defer timer.Stop()
+
+	for {
+		select {
+		case now := <-timer.Chan():
+			first := deadline
+
+			var count int
+			count, deadline = elapsedDeadlines(deadline, duration, now)
+
+			t.m.Lock()
+			t.add(first, duration, count)
+			t.m.Unlock()
+
+			timer.Reset(clock.Until(deadline))
+
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// NewPolicyTicker creates a new ticker tied to the internal MockClock time that
+// handles missed ticks according to the given policy. Unlike a MockTicker, it
+// sees every interval elapsed by a single call to Advance, and a pending tick
+// does not block the clock.
+func (c *MockClock) NewPolicyTicker(duration time.Duration, policy TickPolicy) PolicyTicker {
+	checkTickerInterval(duration)
+
+	c.m.Lock()
+	defer c.unlockAndDispatch()
+
+	c.tickerArgs = append(c.tickerArgs, duration)
+
+	t := &mockPolicyTicker{
+		policyTicker: newPolicyTicker(c.m, policy),
+		duration:     duration,
+		deadline:     c.now.Add(duration),
+	}
+
+	c.register(t)
+	return t
+}
+
+type mockPolicyTicker struct {
+	*policyTicker
+	eventID
+	duration time.Duration
+	deadline time.Time
+}
+
+// signal conforms to the subscriber interface.
+func (t *mockPolicyTicker) signal(now time.Time) (requeue bool) {
+	if t.stopped {
+		return false
+	}
+
+	first := t.deadline
+
+	var count int
+	count, t.deadline = elapsedDeadlines(t.deadline, t.duration, now)
+	t.add(first, t.duration, count)
+	return true
+}
+
+// next conforms to the subscriber interface.
+func (t *mockPolicyTicker) next() (time.Time, bool) {
+	return t.deadline, !t.stopped
+}
+
+// kind conforms to the subscriber interface.
+func (t *mockPolicyTicker) kind() EventKind {
+	return TickerEvent
+}
+
+// save conforms to the restorable interface.
+func (t *mockPolicyTicker) save() func() bool {
+	deadline, stopped := t.deadline, t.stopped
+	pending := append([]Tick(nil), t.pending...)
+
+	return func() bool {
+		if t.stopped && !stopped {
+			t.stopped = false
+			t.done = make(chan struct{})
+			go t.process(t.done)
+		}
+
+		t.deadline = deadline
+		t.pending = append([]Tick(nil), pending...)
+		t.sending = false
+		t.generation++
+		t.cond.Broadcast()
+		return !stopped
+	}
+}
+
+// cancel conforms to the restorable interface.
+func (t *mockPolicyTicker) cancel() {
+	t.stop()
+}
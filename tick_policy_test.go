@@ -0,0 +1,227 @@
+package glock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyTickerDrop(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, DropMissedTicks)
+	defer ticker.Stop()
+
+	clock.Advance(3500 * time.Millisecond)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(1, 0)}))
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+
+	clock.Advance(500 * time.Millisecond)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(4, 0)}))
+}
+
+func TestPolicyTickerCoalesce(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, CoalesceMissedTicks)
+	defer ticker.Stop()
+
+	clock.Advance(3500 * time.Millisecond)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(3, 0), Missed: 2}))
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+
+	clock.Advance(500 * time.Millisecond)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(4, 0)}))
+}
+
+func TestPolicyTickerCoalescePending(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, CoalesceMissedTicks)
+	defer ticker.Stop()
+
+	// The first tick is in flight before the others elapse; they are merged
+	// into a second tick rather than into the one being delivered
+	clock.Advance(time.Second)
+	eventually(t, func() bool {
+		clock.m.Lock()
+		defer clock.m.Unlock()
+		return ticker.(*mockPolicyTicker).sending
+	})
+	clock.Advance(3 * time.Second)
+
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(1, 0)}))
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(4, 0), Missed: 2}))
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+}
+
+func TestPolicyTickerLongAdvance(t *testing.T) {
+	t.Parallel()
+
+	// Dropped and merged ticks are counted rather than buffered, so a long
+	// advance of a short interval is cheap
+	clock := NewMockClockAt(time.Unix(0, 0))
+	drop := clock.NewPolicyTicker(time.Millisecond, DropMissedTicks)
+	defer drop.Stop()
+	coalesce := clock.NewPolicyTicker(time.Millisecond, CoalesceMissedTicks)
+	defer coalesce.Stop()
+
+	clock.Advance(24 * time.Hour)
+	eventually(t, tickReceives(drop.Ticks(), Tick{Time: time.Unix(0, int64(time.Millisecond))}))
+	eventually(t, tickReceives(coalesce.Ticks(), Tick{Time: time.Unix(86400, 0), Missed: 86399999}))
+	consistently(t, tickDoesNotReceive(drop.Ticks()))
+	consistently(t, tickDoesNotReceive(coalesce.Ticks()))
+}
+
+func TestPolicyTickerQueue(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, QueueMissedTicks)
+	defer ticker.Stop()
+
+	clock.Advance(3500 * time.Millisecond)
+	for i := int64(1); i <= 3; i++ {
+		eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(i, 0)}))
+	}
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+}
+
+func TestPolicyTickerStop(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := clock.NewPolicyTicker(time.Second, QueueMissedTicks)
+
+	clock.Advance(3 * time.Second)
+	ticker.Stop()
+	ticker.Stop()
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+
+	clock.Advance(time.Second)
+	_, ok := clock.NextDeadline()
+	assert.False(t, ok)
+}
+
+func TestPolicyTickerNonPositiveDuration(t *testing.T) {
+	t.Parallel()
+
+	for _, clock := range []Clock{NewMockClock(), NewRealClock(), struct{ Clock }{NewMockClock()}} {
+		assert.PanicsWithValue(t, "non-positive interval for NewTicker", func() {
+			NewPolicyTickerOn(clock, 0, DropMissedTicks)
+		})
+	}
+}
+
+func TestPolicyTickerOtherClock(t *testing.T) {
+	t.Parallel()
+
+	// The embedded interface hides the mock's TickerClock methods
+	clock := NewMockClockAt(time.Unix(0, 0))
+	ticker := NewPolicyTickerOn(struct{ Clock }{clock}, time.Second, QueueMissedTicks)
+	defer ticker.Stop()
+
+	clock.Advance(3500 * time.Millisecond)
+	for i := int64(1); i <= 3; i++ {
+		eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(i, 0)}))
+	}
+	consistently(t, tickDoesNotReceive(ticker.Ticks()))
+
+	clock.Advance(500 * time.Millisecond)
+	eventually(t, tickReceives(ticker.Ticks(), Tick{Time: time.Unix(4, 0)}))
+}
+
+func TestRealPolicyTicker(t *testing.T) {
+	t.Parallel()
+
+	ticker := NewPolicyTickerOn(NewRealClock(), 10*time.Millisecond, QueueMissedTicks)
+	defer ticker.Stop()
+
+	// Every tick that elapses while the reader is away is delivered
+	time.Sleep(100 * time.Millisecond)
+
+	var ticks []Tick
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		select {
+		case tick := <-ticker.Ticks():
+			ticks = append(ticks, tick)
+		case <-time.After(time.Second):
+			t.Fatalf("expected a queued tick")
+		}
+	}
+
+	// Queued ticks are delivered back to back, rather than one per interval
+	elapsed := time.Since(start)
+	assert.True(t, elapsed < 10*time.Millisecond, "expected queued ticks without delay, took %s", elapsed)
+
+	for i := 1; i < len(ticks); i++ {
+		assert.Equal(t, 10*time.Millisecond, ticks[i].Time.Sub(ticks[i-1].Time), "expected consecutive scheduled times")
+		assert.Equal(t, 0, ticks[i].Missed)
+	}
+}
+
+func TestRealPolicyTickerCoalesce(t *testing.T) {
+	t.Parallel()
+
+	ticker := NewPolicyTickerOn(NewRealClock(), 10*time.Millisecond, CoalesceMissedTicks)
+	defer ticker.Stop()
+
+	time.Sleep(55 * time.Millisecond)
+
+	missed := 0
+	for received := 0; received < 2; received++ {
+		tick := <-ticker.Ticks()
+		missed += tick.Missed
+	}
+
+	assert.True(t, missed >= 3, "expected at least 3 missed ticks, got %d", missed)
+}
+
+func TestPolicyTickerAsTicker(t *testing.T) {
+	t.Parallel()
+
+	clock := NewMockClockAt(time.Unix(0, 0))
+	policyTicker := clock.NewPolicyTicker(time.Second, QueueMissedTicks)
+
+	var ticker Ticker = PolicyTickerAsTicker(policyTicker)
+	clock.Advance(3 * time.Second)
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(2, 0)))
+	eventually(t, chanReceives(ticker.Chan(), time.Unix(3, 0)))
+	consistently(t, chanDoesNotReceive(ticker.Chan()))
+
+	// Stopping the adapter stops the policy ticker
+	ticker.Stop()
+	ticker.Stop()
+	clock.Advance(time.Second)
+	consistently(t, chanDoesNotReceive(ticker.Chan()))
+	_, ok := clock.NextDeadline()
+	assert.False(t, ok)
+}
+
+func tickReceives(ch <-chan Tick, expected Tick) func() bool {
+	return func() bool {
+		select {
+		case tick := <-ch:
+			return tick == expected
+		default:
+			return false
+		}
+	}
+}
+
+func tickDoesNotReceive(ch <-chan Tick) func() bool {
+	return func() bool {
+		select {
+		case <-ch:
+			return false
+		default:
+			return true
+		}
+	}
+}
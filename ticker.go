@@ -11,3 +11,47 @@ type Ticker interface {
 	// Stop stops the ticker.
 	Stop()
 }
+
+// checkTickerInterval panics if the given ticker interval is not positive,
+// matching the behavior of time.NewTicker.
+func checkTickerInterval(duration time.Duration) {
+	if duration <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+}
+
+// forwardLatestTick forwards ticks read from src to dst until done is closed.
+// Each tick is first passed to accept (if non-nil), which may replace the tick
+// or drop it by returning false. While a tick is waiting for a reader it is
+// replaced by newer ticks, so slow readers see only the latest tick, as they
+// do with time.Ticker. The delivered function (if non-nil) is called with each
+// tick once a reader has received it.
+func forwardLatestTick(src <-chan time.Time, dst chan<- time.Time, done <-chan struct{}, accept func(now time.Time) (time.Time, bool), delivered func(now time.Time)) {
+	var (
+		pending time.Time
+		out     chan<- time.Time // nil while no tick is pending
+	)
+
+	for {
+		select {
+		case now := <-src:
+			if accept != nil {
+				var ok bool
+				if now, ok = accept(now); !ok {
+					continue
+				}
+			}
+
+			pending, out = now, dst
+
+		case out <- pending:
+			if delivered != nil {
+				delivered(pending)
+			}
+			out = nil
+
+		case <-done:
+			return
+		}
+	}
+}
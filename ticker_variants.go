@@ -0,0 +1,151 @@
+package glock
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TickerClock is implemented by clocks that construct ticker variants. The
+// clocks returned by NewRealClock and NewMockClock implement it. To create a
+// ticker variant on any Clock, use the package-level NewTickerImmediateOn,
+// NewJitteredTickerOn, and NewPolicyTickerOn functions.
+type TickerClock interface {
+	Clock
+
+	// NewTickerImmediate creates a ticker that ticks once immediately and
+	// then at the given interval.
+	NewTickerImmediate(duration time.Duration) Ticker
+
+	// NewJitteredTicker creates a ticker whose intervals are drawn uniformly
+	// from the given duration plus or minus the given fraction of it (e.g. a
+	// jitter of 0.1 for ±10%). The jitter must be in [0, 1). Intervals are
+	// drawn from the given random source, or from the default source if nil.
+	// The random source must not be used concurrently by other code.
+	NewJitteredTicker(duration time.Duration, jitter float64, r *rand.Rand) Ticker
+
+	// NewPolicyTicker creates a ticker that handles ticks missed by slow
+	// readers according to the given policy.
+	NewPolicyTicker(duration time.Duration, policy TickPolicy) PolicyTicker
+}
+
+var (
+	_ TickerClock = &realClock{}
+	_ TickerClock = &MockClock{}
+)
+
+// NewTickerImmediateOn creates a ticker on the given clock that ticks once
+// immediately and then at the given interval. If the clock does not implement
+// TickerClock, the ticker is built on the clock's timers.
+func NewTickerImmediateOn(clock Clock, duration time.Duration) Ticker {
+	if c, ok := clock.(TickerClock); ok {
+		return c.NewTickerImmediate(duration)
+	}
+
+	return newVariantTicker(clock, duration, nil, true)
+}
+
+// NewJitteredTickerOn creates a ticker on the given clock whose intervals are
+// drawn as described by TickerClock.NewJitteredTicker. If the clock does not
+// implement TickerClock, the ticker is built on the clock's timers.
+func NewJitteredTickerOn(clock Clock, duration time.Duration, jitter float64, r *rand.Rand) Ticker {
+	if c, ok := clock.(TickerClock); ok {
+		return c.NewJitteredTicker(duration, jitter, r)
+	}
+
+	return newVariantTicker(clock, duration, jitteredInterval(duration, jitter, r), false)
+}
+
+func (c *realClock) NewTickerImmediate(duration time.Duration) Ticker {
+	return newVariantTicker(c, duration, nil, true)
+}
+
+func (c *realClock) NewJitteredTicker(duration time.Duration, jitter float64, r *rand.Rand) Ticker {
+	return newVariantTicker(c, duration, jitteredInterval(duration, jitter, r), false)
+}
+
+// jitteredInterval returns a function that draws intervals uniformly from the
+// given duration plus or minus the given fraction of it. It panics if the
+// jitter is outside of [0, 1).
+func jitteredInterval(duration time.Duration, jitter float64, r *rand.Rand) func() time.Duration {
+	if jitter < 0 || jitter >= 1 {
+		panic("jitter out of range [0, 1) for NewJitteredTicker")
+	}
+
+	float := rand.Float64
+	if r != nil {
+		float = r.Float64
+	}
+
+	return func() time.Duration {
+		interval := duration + time.Duration(float64(duration)*jitter*(2*float()-1))
+		if interval <= 0 {
+			return 1
+		}
+
+		return interval
+	}
+}
+
+// variantTicker is a Ticker whose intervals are drawn from a function. It
+// re-arms a timer of its clock for each tick, dropping ticks for slow readers
+// as time.Ticker does.
+type variantTicker struct {
+	ch   chan time.Time
+	done chan struct{}
+	once sync.Once
+}
+
+func newVariantTicker(clock Clock, duration time.Duration, interval func() time.Duration, immediate bool) Ticker {
+	checkTickerInterval(duration)
+
+	if interval == nil {
+		interval = func() time.Duration { return duration }
+	}
+
+	t := &variantTicker{
+		ch:   make(chan time.Time, 1),
+		done: make(chan struct{}),
+	}
+
+	now := clock.Now()
+	if immediate {
+		t.ch <- now
+	}
+
+	deadline := now.Add(interval())
+	go t.process(clock, clock.NewTimer(clock.Until(deadline)), deadline, interval)
+	return t
+}
+
+func (t *variantTicker) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *variantTicker) Stop() {
+	t.once.Do(func() { close(t.done) })
+}
+
+func (t *variantTicker) process(clock Clock, timer Timer, deadline time.Time, interval func() time.Duration) {
+	defer timer.Stop()
+
+	for {
+		select {
+		case now := <-timer.Chan():
+			select {
+			case t.ch <- now:
+			default:
+			}
+
+			// Skip the ticks missed while this goroutine was not scheduled
+			for !deadline.After(now) {
+				deadline = deadline.Add(interval())
+			}
+
+			timer.Reset(clock.Until(deadline))
+
+		case <-t.done:
+			return
+		}
+	}
+}
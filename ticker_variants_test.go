@@ -0,0 +1,136 @@
+package glock
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTickerImmediate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewMockClockAt(time.Unix(0, 0))
+		ticker := clock.NewTickerImmediate(time.Second)
+		defer ticker.Stop()
+
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(0, 0)))
+		consistently(t, chanDoesNotReceive(ticker.Chan()))
+
+		clock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+		assert.Equal(t, []time.Duration{time.Second}, clock.GetTickerArgs())
+	})
+
+	t.Run("real", func(t *testing.T) {
+		t.Parallel()
+
+		ticker := NewTickerImmediateOn(NewRealClock(), 50*time.Millisecond)
+		defer ticker.Stop()
+
+		start := time.Now()
+		<-ticker.Chan()
+		assert.True(t, time.Since(start) < 25*time.Millisecond, "expected first tick immediately")
+
+		<-ticker.Chan()
+		assert.True(t, time.Since(start) >= 50*time.Millisecond, "expected second tick after interval")
+	})
+
+	t.Run("other clock", func(t *testing.T) {
+		t.Parallel()
+
+		// The embedded interface hides the mock's TickerClock methods
+		clock := NewMockClockAt(time.Unix(0, 0))
+		ticker := NewTickerImmediateOn(struct{ Clock }{clock}, time.Second)
+		defer ticker.Stop()
+
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(0, 0)))
+		consistently(t, chanDoesNotReceive(ticker.Chan()))
+
+		clock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(1, 0)))
+		clock.Advance(time.Second)
+		eventually(t, chanReceives(ticker.Chan(), time.Unix(2, 0)))
+	})
+}
+
+func TestNewJitteredTicker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mock", func(t *testing.T) {
+		t.Parallel()
+
+		deadlines := func(seed int64) []time.Time {
+			clock := NewMockClockAt(time.Unix(0, 0))
+			ticker := clock.NewJitteredTicker(10*time.Second, 0.1, rand.New(rand.NewSource(seed)))
+			defer ticker.Stop()
+
+			var deadlines []time.Time
+			for i := 0; i < 5; i++ {
+				deadline, ok := clock.NextDeadline()
+				assert.True(t, ok)
+				deadlines = append(deadlines, deadline)
+
+				clock.SetCurrent(deadline)
+				eventually(t, chanReceives(ticker.Chan(), deadline))
+			}
+
+			return deadlines
+		}
+
+		first := deadlines(1)
+		assert.Equal(t, first, deadlines(1), "expected a seeded ticker to be reproducible")
+		assert.NotEqual(t, first, deadlines(2))
+
+		previous := time.Unix(0, 0)
+		for _, deadline := range first {
+			interval := deadline.Sub(previous)
+			assert.True(t, interval >= 9*time.Second && interval <= 11*time.Second, "interval %s out of range", interval)
+			previous = deadline
+		}
+	})
+
+	t.Run("real", func(t *testing.T) {
+		t.Parallel()
+
+		ticker := NewJitteredTickerOn(NewRealClock(), 20*time.Millisecond, 0.5, rand.New(rand.NewSource(1)))
+		defer ticker.Stop()
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			<-ticker.Chan()
+		}
+		assert.True(t, time.Since(start) >= 30*time.Millisecond, "expected ticks no sooner than the minimum interval")
+	})
+
+	t.Run("other clock", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewMockClockAt(time.Unix(0, 0))
+		ticker := NewJitteredTickerOn(struct{ Clock }{clock}, 10*time.Second, 0.1, rand.New(rand.NewSource(1)))
+		defer ticker.Stop()
+
+		deadline, ok := clock.NextDeadline()
+		assert.True(t, ok)
+		interval := deadline.Sub(time.Unix(0, 0))
+		assert.True(t, interval >= 9*time.Second && interval <= 11*time.Second, "interval %s out of range", interval)
+
+		clock.SetCurrent(deadline)
+		eventually(t, chanReceives(ticker.Chan(), deadline))
+	})
+
+	t.Run("invalid jitter", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewMockClock()
+		for _, jitter := range []float64{-0.1, 1} {
+			assert.PanicsWithValue(t, "jitter out of range [0, 1) for NewJitteredTicker", func() {
+				clock.NewJitteredTicker(time.Second, jitter, nil)
+			})
+		}
+	})
+}
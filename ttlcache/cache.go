@@ -0,0 +1,268 @@
+// Package ttlcache provides a concurrent map with per-entry expiration whose
+// notion of time is supplied by a glock.Clock.
+package ttlcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+)
+
+// EvictionReason describes why an entry was removed from the cache.
+type EvictionReason int
+
+const (
+	// Expired indicates that the entry's TTL elapsed.
+	Expired EvictionReason = iota
+
+	// Evicted indicates that the entry was the least recently used entry
+	// in a cache that reached its capacity.
+	Evicted
+
+	// Deleted indicates that the entry was removed explicitly.
+	Deleted
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case Expired:
+		return "expired"
+	case Evicted:
+		return "evicted"
+	case Deleted:
+		return "deleted"
+	}
+
+	return "unknown"
+}
+
+// Options configures a Cache.
+type Options[K comparable, V any] struct {
+	// TTL is the time-to-live for entries added via Set. A zero value
+	// means that entries do not expire.
+	TTL time.Duration
+
+	// Sliding controls whether reading an entry with Get extends its
+	// expiration by its TTL.
+	Sliding bool
+
+	// Capacity is the maximum number of entries held by the cache. When
+	// the cache is full, the least recently used entry is evicted. A zero
+	// value means the cache is unbounded.
+	Capacity int
+
+	// OnEvict, if set, is called (outside of the cache's lock) for every
+	// entry removed from the cache.
+	OnEvict func(key K, value V, reason EvictionReason)
+
+	// JanitorInterval, if positive, starts a background goroutine that
+	// removes expired entries on a ticker created by the cache's clock.
+	// Otherwise, expired entries are removed lazily on access.
+	JanitorInterval time.Duration
+}
+
+// Cache is a concurrent map with per-entry TTLs and optional LRU eviction.
+type Cache[K comparable, V any] struct {
+	clock   glock.Clock
+	options Options[K, V]
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+	done    chan struct{}
+	once    sync.Once
+	wg      sync.WaitGroup
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	ttl       time.Duration
+	expiresAt time.Time
+}
+
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// New creates a new Cache with the given options. If a janitor is configured,
+// Close must be called to stop it.
+func New[K comparable, V any](clock glock.Clock, options Options[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		clock:   clock,
+		options: options,
+		entries: map[K]*list.Element{},
+		lru:     list.New(),
+		done:    make(chan struct{}),
+	}
+
+	if options.JanitorInterval > 0 {
+		ticker := clock.NewTicker(options.JanitorInterval)
+
+		c.wg.Add(1)
+		go c.janitor(ticker)
+	}
+
+	return c
+}
+
+// Set adds or replaces the value for the given key using the cache's default TTL.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.options.TTL)
+}
+
+// SetWithTTL adds or replaces the value for the given key, expiring after the
+// given TTL. A zero TTL means the entry does not expire.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+
+	var evictions []eviction[K, V]
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value = value
+		e.ttl = ttl
+		e.expiresAt = c.expiresAt(ttl)
+		c.lru.MoveToFront(el)
+	} else {
+		c.entries[key] = c.lru.PushFront(&entry[K, V]{
+			key:       key,
+			value:     value,
+			ttl:       ttl,
+			expiresAt: c.expiresAt(ttl),
+		})
+
+		if c.options.Capacity > 0 && c.lru.Len() > c.options.Capacity {
+			evictions = append(evictions, c.remove(c.lru.Back(), Evicted))
+		}
+	}
+
+	c.mu.Unlock()
+	c.notify(evictions)
+}
+
+// Get returns the value for the given key and whether it was present and
+// unexpired. Reading an entry marks it as recently used and, if the cache
+// uses sliding expiration, extends its lifetime.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+
+	var evictions []eviction[K, V]
+	if el, found := c.entries[key]; found {
+		e := el.Value.(*entry[K, V])
+
+		if c.expired(e, c.clock.Now()) {
+			evictions = append(evictions, c.remove(el, Expired))
+		} else {
+			if c.options.Sliding {
+				e.expiresAt = c.expiresAt(e.ttl)
+			}
+
+			c.lru.MoveToFront(el)
+			value, ok = e.value, true
+		}
+	}
+
+	c.mu.Unlock()
+	c.notify(evictions)
+	return value, ok
+}
+
+// Delete removes the value for the given key. It returns true if the key
+// was present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+
+	var evictions []eviction[K, V]
+	if el, ok := c.entries[key]; ok {
+		evictions = append(evictions, c.remove(el, Deleted))
+	}
+
+	c.mu.Unlock()
+	c.notify(evictions)
+	return len(evictions) > 0
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not yet been removed.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// DeleteExpired removes all expired entries from the cache.
+func (c *Cache[K, V]) DeleteExpired() {
+	c.mu.Lock()
+
+	now := c.clock.Now()
+	var evictions []eviction[K, V]
+	for el := c.lru.Back(); el != nil; {
+		prev := el.Prev()
+		if c.expired(el.Value.(*entry[K, V]), now) {
+			evictions = append(evictions, c.remove(el, Expired))
+		}
+		el = prev
+	}
+
+	c.mu.Unlock()
+	c.notify(evictions)
+}
+
+// Close stops the janitor goroutine, if any, and waits for it to exit.
+func (c *Cache[K, V]) Close() {
+	c.once.Do(func() { close(c.done) })
+	c.wg.Wait()
+}
+
+func (c *Cache[K, V]) janitor(ticker glock.Ticker) {
+	defer c.wg.Done()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.Chan():
+			c.DeleteExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// expiresAt returns the expiration time of an entry with the given TTL
+// written at the current time. A zero time means the entry does not expire.
+func (c *Cache[K, V]) expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return c.clock.Now().Add(ttl)
+}
+
+func (c *Cache[K, V]) expired(e *entry[K, V], now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// remove drops the given element from the cache. This method assumes the
+// lock is held.
+func (c *Cache[K, V]) remove(el *list.Element, reason EvictionReason) eviction[K, V] {
+	e := c.lru.Remove(el).(*entry[K, V])
+	delete(c.entries, e.key)
+
+	return eviction[K, V]{key: e.key, value: e.value, reason: reason}
+}
+
+// notify invokes the eviction callback for each of the given evictions. This
+// method must be called without the lock held.
+func (c *Cache[K, V]) notify(evictions []eviction[K, V]) {
+	if c.options.OnEvict == nil {
+		return
+	}
+
+	for _, e := range evictions {
+		c.options.OnEvict(e.key, e.value, e.reason)
+	}
+}
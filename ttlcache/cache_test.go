@@ -0,0 +1,141 @@
+package ttlcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get and set", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		cache := New[string, int](clock, Options[string, int]{})
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Set("a", 3)
+
+		assertGet(t, cache, "a", 3)
+		assertGet(t, cache, "b", 2)
+		assertMissing(t, cache, "c")
+		assert.Equal(t, 2, cache.Len())
+
+		clock.Advance(time.Hour)
+		assertGet(t, cache, "a", 3)
+	})
+	t.Run("expiration", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		evictions := &evictionRecorder{}
+		cache := New[string, int](clock, Options[string, int]{TTL: time.Second, OnEvict: evictions.record})
+
+		cache.Set("a", 1)
+		cache.SetWithTTL("b", 2, 2*time.Second)
+		cache.SetWithTTL("c", 3, 0)
+
+		clock.Advance(999 * time.Millisecond)
+		assertGet(t, cache, "a", 1)
+
+		clock.Advance(time.Millisecond)
+		assertMissing(t, cache, "a")
+		assertGet(t, cache, "b", 2)
+
+		clock.Advance(time.Hour)
+		assertMissing(t, cache, "b")
+		assertGet(t, cache, "c", 3)
+
+		assert.Equal(t, []string{"a:expired", "b:expired"}, evictions.get())
+	})
+	t.Run("sliding expiration", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		cache := New[string, int](clock, Options[string, int]{TTL: time.Second, Sliding: true})
+
+		cache.Set("a", 1)
+		for i := 0; i < 5; i++ {
+			clock.Advance(900 * time.Millisecond)
+			assertGet(t, cache, "a", 1)
+		}
+
+		clock.Advance(time.Second)
+		assertMissing(t, cache, "a")
+	})
+	t.Run("capacity", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		evictions := &evictionRecorder{}
+		cache := New[string, int](clock, Options[string, int]{Capacity: 2, OnEvict: evictions.record})
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		assertGet(t, cache, "a", 1) // b is now least recently used
+		cache.Set("c", 3)
+
+		assertGet(t, cache, "a", 1)
+		assertMissing(t, cache, "b")
+		assertGet(t, cache, "c", 3)
+		assert.Equal(t, []string{"b:evicted"}, evictions.get())
+	})
+	t.Run("delete", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		evictions := &evictionRecorder{}
+		cache := New[string, int](clock, Options[string, int]{OnEvict: evictions.record})
+
+		cache.Set("a", 1)
+		assert.True(t, cache.Delete("a"))
+		assert.False(t, cache.Delete("a"))
+		assertMissing(t, cache, "a")
+		assert.Equal(t, []string{"a:deleted"}, evictions.get())
+	})
+	t.Run("janitor", func(t *testing.T) {
+		clock := glock.NewMockClockAt(time.Unix(0, 0))
+		evictions := &evictionRecorder{}
+		cache := New[string, int](clock, Options[string, int]{
+			TTL:             time.Second,
+			OnEvict:         evictions.record,
+			JanitorInterval: 5 * time.Second,
+		})
+		defer cache.Close()
+
+		cache.Set("a", 1)
+		cache.SetWithTTL("b", 2, time.Minute)
+		assert.Equal(t, []time.Duration{5 * time.Second}, clock.GetTickerArgs())
+
+		clock.Advance(time.Second)
+		assert.Never(t, func() bool { return cache.Len() != 2 }, 50*time.Millisecond, time.Millisecond)
+
+		clock.Advance(4 * time.Second)
+		assert.Eventually(t, func() bool { return cache.Len() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, []string{"a:expired"}, evictions.get())
+	})
+}
+
+func assertGet(t *testing.T, cache *Cache[string, int], key string, expected int) {
+	value, ok := cache.Get(key)
+	assert.True(t, ok, "expected key %q to be present", key)
+	assert.Equal(t, expected, value)
+}
+
+func assertMissing(t *testing.T, cache *Cache[string, int], key string) {
+	_, ok := cache.Get(key)
+	assert.False(t, ok, "expected key %q to be missing", key)
+}
+
+type evictionRecorder struct {
+	mu        sync.Mutex
+	evictions []string
+}
+
+func (r *evictionRecorder) record(key string, value int, reason EvictionReason) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evictions = append(r.evictions, key+":"+reason.String())
+}
+
+func (r *evictionRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.evictions...)
+}